@@ -0,0 +1,113 @@
+package vali
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RegisterAlias registers an alias for the given tag string,
+// allowing to use the alias in struct field tags instead of
+// repeating the same tags for multiple fields.
+// The tag string of an alias can use other aliases,
+// but an error is returned if the aliases point to each other.
+// An error is also returned if the name of the alias is already used by a tag.
+// Errors of the aliased tags report both the alias and the tag that failed.
+// Example:
+/*
+
+	err := v.RegisterAlias("username", "required|min=3|max=64|none_of=admin,root")
+
+	type User struct {
+		Name string `vali:"username"`
+	}
+
+*/
+func (v *Vali) RegisterAlias(alias, tg string) error {
+	if alias == "" || tg == "" {
+		return fmt.Errorf("alias and its tags can't be empty")
+	}
+	if _, ok := v.tags[alias]; ok {
+		return fmt.Errorf("alias '%s' is already used as a tag", alias)
+	}
+
+	prev, existed := v.aliases[alias]
+	v.aliases[alias] = tg
+	if _, err := v.expandAliases(parseTags(tg)); err != nil {
+		if existed {
+			v.aliases[alias] = prev
+		} else {
+			delete(v.aliases, alias)
+		}
+		return err
+	}
+
+	v.resetPlans()
+	return nil
+}
+
+// expandAliases replaces the aliases found in the given tags
+// with the tags they're made of.
+func (v *Vali) expandAliases(tgs []tag) ([]tag, error) {
+	if len(v.aliases) == 0 {
+		return tgs, nil
+	}
+
+	return v.expand(tgs, nil)
+}
+
+func (v *Vali) expand(tgs []tag, seen []string) ([]tag, error) {
+	expanded := make([]tag, 0, len(tgs))
+	for _, t := range tgs {
+		if len(t.alts) > 0 {
+			alts := make([][]tag, 0, len(t.alts))
+			for _, alt := range t.alts {
+				exp, err := v.expand(alt, seen)
+				if err != nil {
+					return nil, err
+				}
+				alts = append(alts, exp)
+			}
+			t.alts = alts
+			expanded = append(expanded, t)
+			continue
+		}
+
+		at, ok := v.aliases[t.name]
+		if !ok {
+			expanded = append(expanded, t)
+			continue
+		}
+
+		for _, s := range seen {
+			if s == t.name {
+				return nil, fmt.Errorf("alias '%s' is recursive: %s", t.name, strings.Join(append(seen, t.name), " -> "))
+			}
+		}
+
+		inner, err := v.expand(parseTags(at), append(seen, t.name))
+		if err != nil {
+			return nil, err
+		}
+
+		// A negated alias has to fail as a whole,
+		// so its tags are kept together as a single alternative.
+		if t.negate {
+			t.alts = [][]tag{inner}
+			expanded = append(expanded, t)
+			continue
+		}
+		for _, it := range inner {
+			// Only the outermost alias is reported
+			it.alias = t.name
+			if t.severity != SeverityError {
+				it.severity = t.severity
+			}
+			if len(it.groups) == 0 {
+				it.groups = t.groups
+			}
+			expanded = append(expanded, it)
+		}
+	}
+
+	return expanded, nil
+}
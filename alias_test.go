@@ -0,0 +1,144 @@
+package vali
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestRegisterAlias(t *testing.T) {
+	v := New()
+	tests := []struct {
+		name    string
+		alias   string
+		tag     string
+		wantErr bool
+	}{
+		{
+			name:    "empty alias, should error",
+			alias:   "",
+			tag:     "required",
+			wantErr: true,
+		},
+		{
+			name:    "empty tags, should error",
+			alias:   "a",
+			tag:     "",
+			wantErr: true,
+		},
+		{
+			name:    "alias has the same name as a tag, should error",
+			alias:   requiredTag,
+			tag:     "min=2",
+			wantErr: true,
+		},
+		{
+			name:    "valid alias, should not error",
+			alias:   "username",
+			tag:     "required|min=3|max=64|none_of=admin,root",
+			wantErr: false,
+		},
+		{
+			name:    "alias uses another alias, should not error",
+			alias:   "login",
+			tag:     "username|neq=guest",
+			wantErr: false,
+		},
+		{
+			name:    "alias points to itself, should error",
+			alias:   "self",
+			tag:     "required|self",
+			wantErr: true,
+		},
+		{
+			name:    "alias is redefined to be recursive, should error",
+			alias:   "username",
+			tag:     "login",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := v.RegisterAlias(tt.alias, tt.tag); (err != nil) != tt.wantErr {
+				t.Errorf("Vali.RegisterAlias() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("failed registrations should not be stored", func(t *testing.T) {
+		if _, ok := v.aliases["self"]; ok {
+			t.Error("expected not to find alias `self`")
+		}
+		if v.aliases["username"] != "required|min=3|max=64|none_of=admin,root" {
+			t.Errorf("expected alias `username` to keep its tags, got: %s", v.aliases["username"])
+		}
+	})
+}
+
+func TestValidateAlias(t *testing.T) {
+	type mock struct {
+		Name  string `vali:"username"`
+		Login string `vali:"warn:login"`
+	}
+
+	v := New()
+	if err := v.RegisterAlias("username", "required|min=3|max=64|none_of=admin,root"); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.RegisterAlias("login", "username|neq=guest"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		s            interface{}
+		want         error
+		wantWarnings []error
+	}{
+		{
+			name:         "valid struct, should not error",
+			s:            &mock{Name: "user", Login: "user"},
+			want:         nil,
+			wantWarnings: []error{},
+		},
+		{
+			name: "aliased tags fail, should report the alias and the tag",
+			s:    &mock{Name: "root", Login: "guest"},
+			want: newAggErr().addErr(&FieldError{
+				Field: "Name",
+				Path:  "Name",
+				Tag:   noneofTag,
+				Alias: "username",
+				Err:   errors.New("must have none of [admin root]"),
+			}),
+			wantWarnings: []error{
+				&FieldError{
+					Field: "Login",
+					Path:  "Login",
+					Tag:   neqTag,
+					Alias: "login",
+					Err:   errors.New("guest is equal to guest"),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := v.ValidateReport(tt.s)
+			if got := r.Err(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Vali.ValidateReport() = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(r.Warnings.Sl, tt.wantWarnings) {
+				t.Errorf("Vali.ValidateReport() warnings = %v, want %v", r.Warnings.Sl, tt.wantWarnings)
+			}
+		})
+	}
+
+	t.Run("alias used with Var, should report the alias", func(t *testing.T) {
+		want := "failed 'min' tag of 'username' alias with an error: 'ab is less than 3'"
+		if err := v.Var("ab", "username"); err == nil || err.Error() != want {
+			t.Errorf("Vali.Var() = %v, want %v", err, want)
+		}
+	})
+}
@@ -0,0 +1,191 @@
+package vali
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+const (
+	// typeTag is the tag of the errors returned by `BindValues`
+	// for the values that can't be converted to the type of their field.
+	typeTag = "type"
+
+	formKey  = "form"
+	queryKey = "query"
+)
+
+// BindValues sets the fields of the struct to the given form or query values
+// and validates it. The values are found by the `form` or the `query` tag of the field,
+// fields without either use their field name and fields of nested structs are found by
+// joining the names with a `.`. Slices get an element for every value and
+// `encoding.TextUnmarshaler` types such as `time.Time` are supported.
+// Empty values of fields that are not strings are treated as absent as browsers send
+// them for empty inputs, the fields keep their values and the `required` tag decides about them.
+// Values that can't be converted return a `FieldError` with the `type` tag and
+// their fields are not validated, the errors are returned in the same `AggErr`
+// as the errors of the validation.
+// Example:
+/*
+
+	type Search struct {
+		Query string    `query:"q" vali:"required|max=64"`
+		Page  int       `query:"page" vali:"min=0"`
+		Tags  []string  `query:"tag" vali:">|one_of=a,b"`
+		Since time.Time `query:"since"`
+	}
+
+	var s Search
+	err := v.BindValues(&s, r.URL.Query())
+
+*/
+func (v *Vali) BindValues(s interface{}, vals url.Values) error {
+	errs := newAggErr()
+	if s == nil {
+		return errs.addErr(errors.New("struct is nil"))
+	}
+
+	val := reflect.ValueOf(s)
+	if val.Kind() != reflect.Ptr {
+		return errs.addErr(fmt.Errorf("function only accepts pointer to structs; got %s", val.Kind()))
+	}
+
+	val, _ = derefReflectValue(val)
+	if val.Kind() != reflect.Struct {
+		return errs.addErr(fmt.Errorf("function only accepts structs; got %s", val.Kind()))
+	}
+
+	failed := v.bindValues(val, "", "", vals, errs)
+
+	st := newState(ValidateOptions{})
+	if len(failed) > 0 {
+		st.filter = newFilter(failed, true, nil)
+	}
+	if err := v.validate(s, st); err != nil {
+		if agg, ok := err.(*AggErr); ok {
+			errs.addErr(agg.Sl...)
+		} else {
+			errs.addErr(err)
+		}
+	}
+	return errs.toError()
+}
+
+// bindValues sets the fields of the struct to the values of their names,
+// it returns the paths of the fields which values couldn't be converted.
+// The fields of flattened embedded structs are bound as fields of the struct.
+func (v *Vali) bindValues(val reflect.Value, prefix, path string, vals url.Values, errs *AggErr) []string {
+	failed := []string{}
+	for i := 0; i < val.NumField(); i++ {
+		sf := val.Type().Field(i)
+		fv := val.Field(i)
+		if v.flattens(sf) {
+			failed = append(failed, v.bindEmbedded(fv, prefix, path, vals, errs)...)
+			continue
+		}
+
+		name := valuesName(sf)
+		if !fv.CanSet() || name == "-" {
+			continue
+		}
+
+		key := joinPath(prefix, name)
+		fpath := joinPath(path, sf.Name)
+		if vs, ok := vals[key]; ok {
+			if vs = presentValues(sf.Type, vs); len(vs) == 0 {
+				continue
+			}
+			if err := setValue(fv, vs); err != nil {
+				errs.addErr(&FieldError{
+					Field: sf.Name,
+					Path:  fpath,
+					Tag:   typeTag,
+					Err:   fmt.Errorf("%s can't be converted to %s", strings.Join(vs, valueSep), sf.Type),
+				})
+				failed = append(failed, fpath)
+			}
+			continue
+		}
+
+		if !isNestedStruct(sf.Type) || !hasPrefix(vals, key+pathSep) {
+			continue
+		}
+		if fv.Kind() == reflect.Ptr && fv.IsNil() {
+			fv.Set(reflect.New(sf.Type.Elem()))
+		}
+		derf, _ := derefReflectValue(fv)
+		failed = append(failed, v.bindValues(derf, key, fpath, vals, errs)...)
+	}
+	return failed
+}
+
+// bindEmbedded binds the values to the fields of a flattened embedded struct,
+// nil pointers are only set if any of their fields are bound.
+func (v *Vali) bindEmbedded(fv reflect.Value, prefix, path string, vals url.Values, errs *AggErr) []string {
+	if fv.Kind() != reflect.Ptr {
+		return v.bindValues(fv, prefix, path, vals, errs)
+	}
+	if !fv.IsNil() {
+		return v.bindValues(fv.Elem(), prefix, path, vals, errs)
+	}
+	if !fv.CanSet() {
+		return nil
+	}
+
+	nested := reflect.New(fv.Type().Elem())
+	failed := v.bindValues(nested.Elem(), prefix, path, vals, errs)
+	if len(failed) > 0 || !nested.Elem().IsZero() {
+		fv.Set(nested)
+	}
+	return failed
+}
+
+// valuesName returns the name of the field in form and query values.
+func valuesName(f reflect.StructField) string {
+	for _, key := range []string{formKey, queryKey} {
+		if name := strings.Split(f.Tag.Get(key), valueSep)[0]; name != "" {
+			return name
+		}
+	}
+	return f.Name
+}
+
+// isNestedStruct reports whether the values of the type are bound to its fields,
+// structs which can be set from text such as `time.Time` are not.
+func isNestedStruct(typ reflect.Type) bool {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ.Kind() == reflect.Struct && !reflect.PtrTo(typ).Implements(textUnmarshalerType)
+}
+
+// presentValues returns the values without the empty ones unless
+// the values are set to a string or a slice of strings.
+func presentValues(typ reflect.Type, vals []string) []string {
+	typ = derefType(typ)
+	if typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array {
+		typ = derefType(typ.Elem())
+	}
+	if typ.Kind() == reflect.String && !reflect.PtrTo(typ).Implements(textUnmarshalerType) {
+		return vals
+	}
+
+	present := make([]string, 0, len(vals))
+	for _, val := range vals {
+		if val != "" {
+			present = append(present, val)
+		}
+	}
+	return present
+}
+
+func hasPrefix(vals url.Values, prefix string) bool {
+	for k := range vals {
+		if strings.HasPrefix(k, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,142 @@
+package vali
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type bindAddress struct {
+	City string `form:"city" vali:"required|one_of=Vilnius,Kaunas"`
+}
+
+type bindSearch struct {
+	Query   string       `query:"q" vali:"required|max=5"`
+	Page    int          `query:"page" vali:"min=-1"`
+	Tags    []string     `form:"tag" vali:">|one_of=a,b"`
+	Since   time.Time    `query:"since"`
+	Limit   *uint        `vali:"optional|max=10"`
+	Skip    string       `form:"-"`
+	Address *bindAddress `form:"address" vali:"optional"`
+}
+
+type bindEmbedded struct {
+	bindAddress
+	Zip int `form:"zip"`
+}
+
+func TestBindValues(t *testing.T) {
+	limit := uint(5)
+	tests := []struct {
+		name    string
+		s       interface{}
+		vals    url.Values
+		want    interface{}
+		wantErr error
+	}{
+		{
+			name: "valid values, should be set",
+			s:    &bindSearch{},
+			vals: url.Values{
+				"q":            {"go"},
+				"page":         {"2"},
+				"tag":          {"a", "b"},
+				"since":        {"2020-01-02T00:00:00Z"},
+				"Limit":        {"5"},
+				"Skip":         {"a"},
+				"address.city": {"Vilnius"},
+			},
+			want: &bindSearch{
+				Query:   "go",
+				Page:    2,
+				Tags:    []string{"a", "b"},
+				Since:   time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+				Limit:   &limit,
+				Address: &bindAddress{City: "Vilnius"},
+			},
+		},
+		{
+			name: "values can't be converted, should error with the type tag and skip their tags",
+			s:    &bindSearch{},
+			vals: url.Values{"q": {"go"}, "page": {"first"}, "Limit": {"-1"}},
+			want: &bindSearch{Query: "go"},
+			wantErr: newAggErr().addErr(
+				newTagError("Page", typeTag, errors.New("first can't be converted to int")),
+				newTagError("Limit", typeTag, errors.New("-1 can't be converted to *uint")),
+			),
+		},
+		{
+			name: "conversion and validation fail, should have both errors",
+			s:    &bindSearch{},
+			vals: url.Values{"q": {"golang"}, "tag": {"c"}, "address.city": {"Riga"}, "since": {"today"}},
+			want: &bindSearch{Query: "golang", Tags: []string{"c"}, Address: &bindAddress{City: "Riga"}},
+			wantErr: newAggErr().addErr(
+				newTagError("Since", typeTag, errors.New("today can't be converted to time.Time")),
+				newTagError("Query", maxTag, errors.New("golang is more than 5")),
+				withPath(newTagError("Tags", oneofTag, errors.New("must have at least one of [a b]")), "Tags[0]"),
+				newAggErr().addErr(withPath(newTagError("City", oneofTag, errors.New("must have at least one of [Vilnius Kaunas]")), "Address.City")),
+			),
+		},
+		{
+			name: "empty values of fields that are not strings, should be treated as absent",
+			s:    &bindSearch{Page: 1},
+			vals: url.Values{"q": {""}, "page": {""}, "since": {""}, "Limit": {"", ""}, "tag": {""}},
+			want: &bindSearch{Page: 1, Tags: []string{""}},
+			wantErr: newAggErr().addErr(
+				newTagError("Query", requiredTag, errors.New("empty string")),
+				withPath(newTagError("Tags", oneofTag, errors.New("must have at least one of [a b]")), "Tags[0]"),
+			),
+		},
+		{
+			name: "empty value of a required field that is not a string, should error with the required tag",
+			s: &struct {
+				N int `vali:"required"`
+			}{},
+			vals: url.Values{"N": {""}},
+			want: &struct {
+				N int `vali:"required"`
+			}{},
+			wantErr: newAggErr().addErr(newTagError("N", requiredTag, errors.New("empty int"))),
+		},
+		{
+			name: "nested value can't be converted, should have the path of the field",
+			s:    &struct{ In struct{ N int } }{},
+			vals: url.Values{"In.N": {"a"}},
+			want: &struct{ In struct{ N int } }{},
+			wantErr: newAggErr().addErr(
+				withPath(newTagError("N", typeTag, errors.New("a can't be converted to int")), "In.N"),
+			),
+		},
+		{
+			name: "embedded struct, should bind its fields as fields of the struct",
+			s:    &bindEmbedded{},
+			vals: url.Values{"city": {"Riga"}, "zip": {"a"}},
+			want: &bindEmbedded{bindAddress: bindAddress{City: "Riga"}},
+			wantErr: newAggErr().addErr(
+				newTagError("Zip", typeTag, errors.New("a can't be converted to int")),
+				newTagError("City", oneofTag, errors.New("must have at least one of [Vilnius Kaunas]")),
+			),
+		},
+		{
+			name:    "not a pointer, should error",
+			s:       bindSearch{},
+			vals:    url.Values{},
+			want:    bindSearch{},
+			wantErr: newAggErr().addErr(errors.New("function only accepts pointer to structs; got struct")),
+		},
+	}
+
+	v := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := v.BindValues(tt.s, tt.vals); !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("Vali.BindValues() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(tt.s, tt.want) {
+				t.Errorf("Vali.BindValues() = %+v, want %+v", tt.s, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+// Command vali validates JSON documents against the types
+// registered with the `valicli` package.
+//
+// This command has no types registered, it's meant to be copied in to
+// a project and to import the packages which register the types:
+//
+//	import _ "example.com/app/fixtures"
+//
+// Usage:
+//
+//	vali [-json] Type [file ...]
+package main
+
+import (
+	"os"
+
+	"github.com/tomasmik/vali/valicli"
+)
+
+func main() {
+	os.Exit(valicli.Main(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
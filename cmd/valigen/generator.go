@@ -0,0 +1,452 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/tomasmik/vali"
+)
+
+const tagName = "vali"
+
+// load type checks the package in the directory, leaving out the output file.
+// Type errors are ignored, fields with types that can't
+// be resolved are validated by the validator.
+func load(dir, exclude string) (*types.Package, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go") && fi.Name() != exclude
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("expected a single package in %s, found %d", dir, len(pkgs))
+	}
+
+	var files []*ast.File
+	for _, pkg := range pkgs {
+		for _, f := range pkg.Files {
+			files = append(files, f)
+		}
+	}
+
+	conf := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+		Error:    func(error) {},
+	}
+	pkg, _ := conf.Check(filepath.Base(dir), fset, files, nil)
+	return pkg, nil
+}
+
+func generate(dir, exclude string, names []string) ([]byte, error) {
+	pkg, err := load(dir, exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &generator{pkg: pkg}
+	for _, name := range names {
+		obj := pkg.Scope().Lookup(name)
+		if obj == nil {
+			return nil, fmt.Errorf("type %s not found", name)
+		}
+		st, ok := obj.Type().Underlying().(*types.Struct)
+		if !ok {
+			return nil, fmt.Errorf("type %s is not a struct", name)
+		}
+		g.genType(name, st)
+	}
+
+	return g.source()
+}
+
+type generator struct {
+	pkg  *types.Package
+	buf  bytes.Buffer
+	errs bool
+	fmt  bool
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+func (g *generator) source() ([]byte, error) {
+	head := &bytes.Buffer{}
+	fmt.Fprintf(head, "// Code generated by valigen; DO NOT EDIT.\n\n")
+	fmt.Fprintf(head, "package %s\n\n", g.pkg.Name())
+	fmt.Fprintf(head, "import (\n")
+	if g.errs {
+		fmt.Fprintf(head, "\t\"errors\"\n")
+	}
+	if g.fmt {
+		fmt.Fprintf(head, "\t\"fmt\"\n")
+	}
+	fmt.Fprintf(head, "\n\t\"github.com/tomasmik/vali\"\n)\n")
+
+	src := append(head.Bytes(), g.buf.Bytes()...)
+	out, err := format.Source(src)
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+	return out, nil
+}
+
+func (g *generator) genType(name string, st *types.Struct) {
+	g.printf("\n// ValidateVali validates the struct using the validator returned by `vali.DefaultVali`.\n")
+	g.printf("func (x *%s) ValidateVali() error {\n", name)
+	g.printf("return x.ValidateValiWith(vali.DefaultVali().GenState(x))\n}\n")
+
+	g.printf("\n// ValidateValiWith validates the struct using the given validation state.\n")
+	g.printf("func (x *%s) ValidateValiWith(g *vali.GenState) error {\n", name)
+	g.printf("if g.Type() {\nreturn g.Err()\n}\n")
+
+	var funcs bytes.Buffer
+	for i := 0; i < st.NumFields(); i++ {
+		f := st.Field(i)
+		vtag := reflect.StructTag(st.Tag(i)).Get(tagName)
+		if !f.Exported() || vtag == "" || vtag == "-" {
+			continue
+		}
+
+		body, ok := g.genField(st, f, vali.ParseTags(vtag))
+		if !ok {
+			g.printf("if g.Field(%q) {\nreturn g.Err()\n}\n", f.Name())
+			continue
+		}
+
+		fn := "valigen" + name + f.Name()
+		g.printf("if err := %s(x); err != nil && g.Fail(err) {\nreturn g.Err()\n}\n", fn)
+		fmt.Fprintf(&funcs, "\nfunc %s(x *%s) error {\n%sreturn nil\n}\n", fn, name, body)
+	}
+	g.printf("return g.Err()\n}\n")
+	g.buf.Write(funcs.Bytes())
+}
+
+// field holds what's needed to generate the checks of a field.
+type field struct {
+	name string
+	expr string
+	typ  types.Type
+}
+
+// genField returns the checks of the field tags, it reports false
+// if any of the tags or the type of the field can't be generated.
+func (g *generator) genField(st *types.Struct, f *types.Var, tags []vali.ParsedTag) (string, bool) {
+	if f.Anonymous() || !validTags(tags) {
+		return "", false
+	}
+
+	fd := field{name: f.Name(), expr: "x." + f.Name(), typ: f.Type()}
+	var body strings.Builder
+	for _, t := range tags {
+		if t.Negate || len(t.Alts) > 0 || len(t.Groups) > 0 || t.Warn {
+			return "", false
+		}
+
+		code, ok := g.genTag(st, fd, t)
+		if !ok {
+			return "", false
+		}
+		body.WriteString(code)
+	}
+	return body.String(), true
+}
+
+// validTags reports whether the field tags pass the same check `Validate` runs,
+// fields with tags that don't pass are left to the validator to report them.
+func validTags(tags []vali.ParsedTag) bool {
+	found := map[string]struct{}{}
+	for _, t := range tags {
+		switch t.Name {
+		case "optional", "required", "required_without":
+			if !t.Negate && len(t.Alts) == 0 {
+				found[t.Name] = struct{}{}
+			}
+		}
+	}
+	return len(found) <= 1
+}
+
+func (g *generator) genTag(st *types.Struct, fd field, t vali.ParsedTag) (string, bool) {
+	k := kindOf(fd.typ)
+	if len(t.Refs) > 0 && t.Name != "required_without" {
+		return "", false
+	}
+
+	switch t.Name {
+	case "required":
+		zero, msg, ok := g.zero(fd)
+		if !ok {
+			return "", false
+		}
+		return fmt.Sprintf("if %s {\nreturn %s\n}\n", zero, g.fieldError(fd, t.Name, msg)), true
+	case "optional":
+		zero, _, ok := g.zero(fd)
+		if !ok {
+			return "", false
+		}
+		return fmt.Sprintf("if %s {\nreturn nil\n}\n", zero), true
+	case "required_without":
+		return g.genRequiredWithout(st, fd, t)
+	case "dups":
+		return g.genDups(fd, t)
+	}
+
+	if len(t.Args) == 0 {
+		return "", false
+	}
+	// The arguments are converted the same way the values are compared
+	var args []string
+	switch {
+	case k == kindString && (t.Name == "min" || t.Name == "max"):
+		// The length of a string is compared to the argument
+		lit, ok := numberLit(kindInt, t.Args[0])
+		if !ok {
+			return "", false
+		}
+		args = append(args, lit)
+	case k == kindString:
+		for _, arg := range t.Args {
+			args = append(args, strconv.Quote(fmt.Sprint(arg)))
+		}
+	case k == kindInt, k == kindUint, k == kindFloat, k == kindSlice:
+		for _, arg := range t.Args {
+			lit, ok := numberLit(k, arg)
+			if !ok {
+				return "", false
+			}
+			args = append(args, lit)
+		}
+	default:
+		return "", false
+	}
+
+	have := fd.expr
+	switch k {
+	case kindString, kindSlice:
+		have = "len(" + fd.expr + ")"
+	case kindInt:
+		have = "int64(" + fd.expr + ")"
+	case kindUint:
+		have = "uint64(" + fd.expr + ")"
+	case kindFloat:
+		have = "float64(" + fd.expr + ")"
+	}
+	// Strings are compared by their length only by min and max
+	value := have
+	if k == kindString {
+		value = fd.expr
+	}
+
+	arg := fmt.Sprint(t.Args[0])
+	var cond, msg string
+	switch t.Name {
+	case "min":
+		cond = fmt.Sprintf("%s <= %s", have, args[0])
+		if k == kindString {
+			cond = fmt.Sprintf("%s < %s", have, args[0])
+		}
+		msg = "%v is less than " + arg
+	case "max":
+		cond = fmt.Sprintf("%s > %s", have, args[0])
+		if k == kindString {
+			cond = fmt.Sprintf("%s >= %s", have, args[0])
+		}
+		msg = "%v is more than " + arg
+	case "eq":
+		cond = fmt.Sprintf("%s != %s", value, args[0])
+		msg = "%v is not equal to " + arg
+	case "neq":
+		cond = fmt.Sprintf("%s == %s", value, args[0])
+		msg = "%v is equal to " + arg
+	case "one_of", "none_of":
+		if k == kindSlice {
+			return "", false
+		}
+		conds := make([]string, 0, len(args))
+		for _, a := range args {
+			conds = append(conds, fmt.Sprintf("%s == %s", value, a))
+		}
+		cond = "!(" + strings.Join(conds, " || ") + ")"
+		msg = fmt.Sprintf("must have at least one of %v", t.Args)
+		if t.Name == "none_of" {
+			cond = strings.Join(conds, " || ")
+			msg = fmt.Sprintf("must have none of %v", t.Args)
+		}
+		return fmt.Sprintf("if %s {\nreturn %s\n}\n", cond, g.fieldError(fd, t.Name, g.constErr(msg))), true
+	default:
+		return "", false
+	}
+
+	return fmt.Sprintf("if %s {\nreturn %s\n}\n", cond, g.fieldError(fd, t.Name, g.valueErr(msg, fd.expr))), true
+}
+
+// genRequiredWithout checks the fields pointed to only if the field is zero valued,
+// skipping the remaining tags if all of them are set.
+func (g *generator) genRequiredWithout(st *types.Struct, fd field, t vali.ParsedTag) (string, bool) {
+	if len(t.Args) > 0 || len(t.Refs) == 0 {
+		return "", false
+	}
+	zero, _, ok := g.zero(fd)
+	if !ok {
+		return "", false
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "if %s {\n", zero)
+	for _, ref := range t.Refs {
+		rf, ok := refField(st, ref)
+		if !ok {
+			return "", false
+		}
+		rzero, msg, ok := g.zero(rf)
+		if !ok {
+			return "", false
+		}
+		fmt.Fprintf(&b, "if %s {\nreturn %s\n}\n", rzero, g.fieldError(fd, t.Name, msg))
+	}
+	b.WriteString("return nil\n}\n")
+	return b.String(), true
+}
+
+func (g *generator) genDups(fd field, t vali.ParsedTag) (string, bool) {
+	sl, ok := fd.typ.Underlying().(*types.Slice)
+	if !ok || kindOf(sl.Elem()) == kindUnsupported || kindOf(sl.Elem()) == kindSlice {
+		return "", false
+	}
+	elem, ok := g.typeExpr(sl.Elem())
+	if !ok {
+		return "", false
+	}
+
+	return fmt.Sprintf(`{
+	seen := make(map[%s]struct{}, len(%s))
+	for _, e := range %s {
+		seen[e] = struct{}{}
+	}
+	if len(seen) != len(%s) {
+		return %s
+	}
+}
+`, elem, fd.expr, fd.expr, fd.expr, g.fieldError(fd, t.Name, g.constErr("no duplicates allowed"))), true
+}
+
+// zero returns the condition which is true if the field fails
+// the `required` tag and the error the tag returns.
+func (g *generator) zero(fd field) (string, string, bool) {
+	name, ok := reflectName(fd.typ)
+	if !ok {
+		return "", "", false
+	}
+
+	switch kindOf(fd.typ) {
+	case kindString:
+		return fd.expr + ` == ""`, g.constErr("empty " + name), true
+	case kindBool:
+		return "!" + fd.expr, g.constErr("empty " + name), true
+	case kindInt, kindUint, kindFloat, kindDuration:
+		return fd.expr + " == 0", g.constErr("empty " + name), true
+	case kindSlice, kindMap:
+		return fd.expr + " == nil", g.constErr(name + " is nil"), true
+	}
+	return "", "", false
+}
+
+func (g *generator) fieldError(fd field, tag, err string) string {
+	return fmt.Sprintf("&vali.FieldError{Field: %q, Tag: %q, Err: %s}", fd.name, tag, err)
+}
+
+func (g *generator) constErr(msg string) string {
+	g.errs = true
+	return fmt.Sprintf("errors.New(%s)", strconv.Quote(msg))
+}
+
+// valueErr returns an error with the message, the `%v` verb is the value of the field.
+func (g *generator) valueErr(msg, expr string) string {
+	g.fmt = true
+	msg = strings.ReplaceAll(msg, "%", "%%")
+	msg = strings.Replace(msg, "%%v", "%v", 1)
+	return fmt.Sprintf("fmt.Errorf(%s, %s)", strconv.Quote(msg), expr)
+}
+
+// typeExpr returns the type as it's written in the generated package.
+func (g *generator) typeExpr(typ types.Type) (string, bool) {
+	switch t := typ.(type) {
+	case *types.Basic:
+		return t.Name(), true
+	case *types.Named:
+		if t.Obj().Pkg() != g.pkg || t.TypeArgs().Len() > 0 {
+			return "", false
+		}
+		return t.Obj().Name(), true
+	}
+	return "", false
+}
+
+func refField(st *types.Struct, name string) (field, bool) {
+	for i := 0; i < st.NumFields(); i++ {
+		f := st.Field(i)
+		if f.Name() == name && f.Exported() {
+			return field{name: name, expr: "x." + name, typ: f.Type()}, true
+		}
+	}
+	return field{}, false
+}
+
+// numberLit returns the argument as a literal of the kind,
+// the same way the argument is converted by the built-in tags.
+func numberLit(k kind, arg interface{}) (string, bool) {
+	switch k {
+	case kindInt:
+		n, ok := arg.(int64)
+		return strconv.FormatInt(n, 10), ok
+	case kindUint:
+		n, ok := arg.(int64)
+		return strconv.FormatUint(uint64(n), 10), ok
+	case kindSlice:
+		n, ok := arg.(int64)
+		return strconv.FormatInt(n, 10), ok && n >= 0
+	case kindFloat:
+		f, ok := arg.(float64)
+		if !ok || math.IsNaN(f) || math.IsInf(f, 0) {
+			return "", false
+		}
+		return strconv.FormatFloat(f, 'g', -1, 64), true
+	}
+	return "", false
+}
+
+// reflectName returns the name of the type the way `reflect.Type.String` does.
+func reflectName(typ types.Type) (string, bool) {
+	switch t := typ.(type) {
+	case *types.Basic:
+		return types.Typ[t.Kind()].Name(), t.Kind() != types.Invalid
+	case *types.Named:
+		if t.Obj().Pkg() == nil || t.TypeArgs().Len() > 0 {
+			return "", false
+		}
+		return t.Obj().Pkg().Name() + "." + t.Obj().Name(), true
+	case *types.Slice:
+		elem, ok := reflectName(t.Elem())
+		return "[]" + elem, ok
+	case *types.Map:
+		key, ok := reflectName(t.Key())
+		elem, ok2 := reflectName(t.Elem())
+		return "map[" + key + "]" + elem, ok && ok2
+	}
+	return "", false
+}
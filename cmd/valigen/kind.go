@@ -0,0 +1,49 @@
+package main
+
+import "go/types"
+
+// kind is the way the values of a type are compared by the built-in tags.
+type kind int
+
+const (
+	kindUnsupported kind = iota
+	kindString
+	kindBool
+	kindInt
+	kindUint
+	kindFloat
+	kindSlice
+	kindMap
+	// kindDuration can only be checked by the `required` tag,
+	// it's not supported by the comparing tags.
+	kindDuration
+)
+
+func kindOf(typ types.Type) kind {
+	if n, ok := typ.(*types.Named); ok && n.Obj().Pkg() != nil &&
+		n.Obj().Pkg().Path() == "time" && n.Obj().Name() == "Duration" {
+		return kindDuration
+	}
+
+	switch t := typ.Underlying().(type) {
+	case *types.Slice:
+		return kindSlice
+	case *types.Map:
+		return kindMap
+	case *types.Basic:
+		info := t.Info()
+		switch {
+		case info&types.IsString != 0:
+			return kindString
+		case info&types.IsBoolean != 0:
+			return kindBool
+		case info&types.IsUnsigned != 0:
+			return kindUint
+		case info&types.IsInteger != 0:
+			return kindInt
+		case info&types.IsFloat != 0:
+			return kindFloat
+		}
+	}
+	return kindUnsupported
+}
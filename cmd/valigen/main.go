@@ -0,0 +1,55 @@
+// Command valigen generates reflection free validation methods
+// for structs tagged with vali tags.
+//
+// For every given type it writes a `ValidateVali` method, which uses the
+// validator returned by `vali.DefaultVali`, and a `ValidateValiWith` method,
+// which `Vali.Validate` calls instead of validating the struct with reflection.
+// The built-in tags are turned in to code, fields with any other tags
+// are validated by the validator the same way `Validate` validates them.
+//
+// Usage:
+//
+//	//go:generate valigen -type=User,Order
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	typeNames = flag.String("type", "", "comma-separated list of type names; must be set")
+	output    = flag.String("output", "", "output file name; default srcdir/<type>_vali.go")
+)
+
+func main() {
+	flag.Parse()
+	if *typeNames == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+
+	names := strings.Split(*typeNames, ",")
+	out := *output
+	if out == "" {
+		out = filepath.Join(dir, strings.ToLower(names[0])+"_vali.go")
+	}
+
+	src, err := generate(dir, filepath.Base(out), names)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "valigen: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(out, src, 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "valigen: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	dir := filepath.Join("..", "..", "internal", "gentest")
+	want, err := os.ReadFile(filepath.Join(dir, "gentest_vali.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := generate(dir, "gentest_vali.go", []string{"User", "Address"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("generate() = %s, want %s", got, want)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	dir := filepath.Join("..", "..", "internal", "gentest")
+	tests := []struct {
+		name  string
+		types []string
+	}{
+		{
+			name:  "unknown type",
+			types: []string{"Unknown"},
+		},
+		{
+			name:  "not a struct",
+			types: []string{"Status"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := generate(dir, "gentest_vali.go", tt.types); err == nil {
+				t.Error("generate() expected an error")
+			}
+		})
+	}
+}
@@ -76,6 +76,19 @@ func (c *cmp) do(s interface{}, o []interface{}) (bool, error) {
 			return c.duration(have, o)
 		}
 		return false, err(reflect.TypeOf(s).String())
+	}
+
+	// Named types are compared by their underlying kind
+	v := reflect.ValueOf(s)
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return c.do(v.Float(), o)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return c.do(v.Int(), o)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return c.do(v.Uint(), o)
+	case reflect.String:
+		return c.do(v.String(), o)
 	default:
 		return false, fmt.Errorf("comparing type %v to other values is not supported", reflect.TypeOf(s).String())
 	}
@@ -136,28 +149,25 @@ func newOneOfCMP(s interface{}, o []interface{}) *cmp {
 func newMinCMP(s interface{}, o []interface{}) *cmp {
 	return &cmp{
 		float: func(have float64, exp []interface{}) (bool, error) {
-			val, _ := GetFloat(s)
 			more, ok := GetFloat(exp[0])
 			if !ok {
 				return false, typeMismatch(s, exp[0])
 			}
-			return val > more, nil
+			return have > more, nil
 		},
 		int: func(have int64, exp []interface{}) (bool, error) {
-			val, _ := GetInt(s)
 			more, ok := GetInt(exp[0])
 			if !ok {
 				return false, typeMismatch(s, exp[0])
 			}
-			return val > more, nil
+			return have > more, nil
 		},
 		uint: func(have uint64, exp []interface{}) (bool, error) {
-			val, _ := GetUInt(s)
 			more, ok := GetUIntFallback(exp[0])
 			if !ok {
 				return false, typeMismatch(s, exp[0])
 			}
-			return val > more, nil
+			return have > more, nil
 		},
 		time: func(have time.Time, exp []interface{}) (bool, error) {
 			more, ok := exp[0].(time.Time)
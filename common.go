@@ -3,24 +3,137 @@ package vali
 import (
 	"fmt"
 	"reflect"
+	"strings"
 )
 
-type tagError struct {
-	field string
-	tag   string
-	err   error
+// FieldError is the error that is returned when a struct
+// field fails the validation of one of its tags.
+type FieldError struct {
+	// Field is the name of the struct field.
+	Field string
+	// Path is the path of the field from the validated struct,
+	// the names of nested struct fields are separated by a `.`
+	// and the elements validated with a dive get their index - "Address.City", "Tags[1]".
+	Path string
+	// Tag is the name of the tag that failed.
+	Tag string
+	// Alias is the name of the alias the failed tag is a part of,
+	// it's empty if the tag was not used through an alias.
+	Alias string
+	// Err is the error returned by the tag func.
+	Err error
+	// Type is the concrete type held by the interface field the struct of the field
+	// was validated through, it's nil for the structs that are not held by an interface.
+	Type reflect.Type
 }
 
 func newTagError(field, tag string, err error) error {
-	return &tagError{
-		field: field,
-		tag:   tag,
-		err:   err,
+	return &FieldError{
+		Field: field,
+		Path:  field,
+		Tag:   tag,
+		Err:   err,
 	}
 }
 
-func (t *tagError) Error() string {
-	return fmt.Sprintf("field: '%s', failed '%s' tag with an error: '%v'", t.field, t.tag, t.err)
+// fieldError returns the error of the field at the given path failing the tag.
+func (t tag) fieldError(field, path string, err error) error {
+	return &FieldError{
+		Field: field,
+		Path:  path,
+		Tag:   t.displayName(),
+		Alias: t.alias,
+		Err:   err,
+	}
+}
+
+func (t *FieldError) Error() string {
+	tg := fmt.Sprintf("'%s' tag", t.Tag)
+	if t.Alias != "" {
+		tg = fmt.Sprintf("'%s' tag of '%s' alias", t.Tag, t.Alias)
+	}
+
+	// Values validated with `Var` have no field name
+	if t.Field == "" {
+		return fmt.Sprintf("failed %s with an error: '%v'", tg, t.Err)
+	}
+	if t.Type != nil {
+		return fmt.Sprintf("field: '%s' of '%v', failed %s with an error: '%v'", t.Field, t.Type, tg, t.Err)
+	}
+	return fmt.Sprintf("field: '%s', failed %s with an error: '%v'", t.Field, tg, t.Err)
+}
+
+// Unwrap returns the error returned by the tag func.
+func (t *FieldError) Unwrap() error {
+	return t.Err
+}
+
+// PathBy returns the elements of the path of the field with the names of the struct
+// fields replaced by the names `name` returns, `typ` is the type of the validated struct.
+// The indexes of the elements validated with a dive are kept in their brackets, fields
+// of embedded structs are found by their promoted names and the fields that can't be
+// found, such as the ones of structs held by an interface, keep their Go names.
+// Example:
+/*
+
+	// Path "Address.Tags[1]"
+	elems := fe.PathBy(reflect.TypeOf(User{}), func(f reflect.StructField) string {
+		return f.Tag.Get("json")
+	})
+	// elems = ["address", "tags", "[1]"]
+
+*/
+func (t *FieldError) PathBy(typ reflect.Type, name func(reflect.StructField) string) []string {
+	elems := make([]string, 0)
+	if t.Path == "" {
+		return elems
+	}
+
+	for _, seg := range strings.Split(t.Path, pathSep) {
+		field, idx := seg, ""
+		if i := strings.IndexByte(seg, '['); i >= 0 {
+			field, idx = seg[:i], seg[i:]
+		}
+
+		if typ = derefType(typ); typ != nil && typ.Kind() == reflect.Struct {
+			f, ok := typ.FieldByName(field)
+			typ = nil
+			if ok && f.PkgPath == "" {
+				field = name(f)
+				typ = f.Type
+			}
+		} else {
+			typ = nil
+		}
+		elems = append(elems, field)
+
+		for _, i := range strings.SplitAfter(idx, "]") {
+			if i == "" {
+				continue
+			}
+			elems = append(elems, i)
+			if typ = derefType(typ); typ != nil && (typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array) {
+				typ = typ.Elem()
+			} else {
+				typ = nil
+			}
+		}
+	}
+	return elems
+}
+
+// withStructType sets the concrete type of an interface field to the errors of the
+// fields of the struct it holds, the errors of the nested structs keep their own.
+func withStructType(err error, typ reflect.Type) {
+	agg, ok := err.(*AggErr)
+	if !ok {
+		return
+	}
+	for _, e := range agg.Sl {
+		if fe, ok := e.(*FieldError); ok && fe.Type == nil {
+			fe.Type = typ
+		}
+	}
 }
 
 func typeMismatch(i, o interface{}) error {
@@ -0,0 +1,138 @@
+package vali
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OrErr is the error returned when every alternative of an OR group fails.
+type OrErr struct {
+	// Sl holds the error of every alternative in the order they were given.
+	Sl []error
+}
+
+func (e *OrErr) Error() string {
+	s := make([]string, 0, len(e.Sl))
+	for _, err := range e.Sl {
+		s = append(s, err.Error())
+	}
+	return fmt.Sprintf("every alternative failed: %s", strings.Join(s, "; "))
+}
+
+// runTag validates the value `c` using the given tag.
+// It returns false if the tag has no validation func.
+func (v *Vali) runTag(t tag, c interface{}) (Result, bool) {
+	var res Result
+	if len(t.alts) > 0 {
+		res = v.runAlts(t.alts, c)
+	} else {
+		fn, ok := v.tags[t.name]
+		if !ok {
+			return Result{}, false
+		}
+		res = fn(c, t.args)
+	}
+
+	if t.negate {
+		res = negate(t, res)
+	}
+	return res, true
+}
+
+// runAlts validates the value `c` using the alternatives of an OR group,
+// returning the result of the first alternative that passes. A skip of the
+// alternative that passed skips the rest of the tags of the field.
+func (v *Vali) runAlts(alts [][]tag, c interface{}) Result {
+	errs := make([]error, 0, len(alts))
+	for _, alt := range alts {
+		res, err := v.runChain(alt, c)
+		if res.BubbleErr != nil || err == nil {
+			return res
+		}
+		errs = append(errs, err)
+	}
+
+	return Result{ValidationErr: &OrErr{Sl: errs}}
+}
+
+// runChain validates the value `c` using the tags of a
+// single alternative, returning the error of the tag that failed.
+func (v *Vali) runChain(chain []tag, c interface{}) (Result, error) {
+	out := Result{}
+	for _, t := range chain {
+		res, ok := v.runTag(t, c)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case res.BubbleErr != nil:
+			return res, nil
+		case res.ValidationErr != nil:
+			return res, t.fieldError("", "", res.ValidationErr)
+		}
+		// The skip ends the alternative and the tags after the OR group
+		if res.Skip {
+			out.Skip = true
+			break
+		}
+		if res.Replace {
+			c = res.Value
+			out.Replace = true
+			out.Value = c
+		}
+	}
+
+	return out, nil
+}
+
+// checkSyntax returns the syntax error of the tags, OR groups which
+// alternatives have different severities or groups are rejected as well.
+func checkSyntax(tgs []tag) error {
+	for _, t := range tgs {
+		if t.err != nil {
+			return t.err
+		}
+		for _, alt := range t.alts {
+			if err := checkSyntax(alt); err != nil {
+				return err
+			}
+		}
+		// A negated alias is a group with a single alternative
+		if len(t.alts) < 2 {
+			continue
+		}
+
+		var first *tag
+		for _, alt := range t.alts {
+			for i, at := range alt {
+				if first == nil {
+					first = &alt[i]
+				}
+				if at.severity != first.severity {
+					return fmt.Errorf("alternatives of '%s' have different severities, every alternative needs the same '%s' prefix", t.name, warnPrefix)
+				}
+				if strings.Join(at.groups, groupListSep) != strings.Join(first.groups, groupListSep) {
+					return fmt.Errorf("alternatives of '%s' have different groups, every alternative needs the same groups", t.name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// negate inverts the outcome of the tag, bubbled errors
+// and skips are still returned as is.
+func negate(t tag, res Result) Result {
+	if res.BubbleErr != nil {
+		return res
+	}
+	if res.ValidationErr != nil {
+		return Result{}
+	}
+	if res.Skip {
+		return Result{Skip: true}
+	}
+
+	return Result{ValidationErr: fmt.Errorf("must not pass '%s' tag", t.name)}
+}
@@ -0,0 +1,192 @@
+package vali
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestValidateComposition(t *testing.T) {
+	type mock struct {
+		First string `vali:"required|eq=a||eq=b||one_of=c,d"`
+	}
+	type mock2 struct {
+		First string `vali:"not(one_of=admin,root)"`
+	}
+	type mock3 struct {
+		First  int `vali:"max=*Second||eq=10"`
+		Second int
+	}
+	type mock4 struct {
+		First string `vali:"not(reserved)||eq=root"`
+	}
+	type mock5 struct {
+		First string `vali:"optional|not(required)"`
+	}
+	type mock6 struct {
+		First string `vali:"optional||eq=x|min=3"`
+	}
+
+	v := New()
+	if err := v.RegisterAlias("reserved", "one_of=admin,root"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		s    interface{}
+		want error
+	}{
+		{
+			name: "first alternative passes, should not error",
+			s:    &mock{First: "a"},
+			want: nil,
+		},
+		{
+			name: "last alternative passes, should not error",
+			s:    &mock{First: "d"},
+			want: nil,
+		},
+		{
+			name: "every alternative fails, should list all of them",
+			s:    &mock{First: "e"},
+			want: newAggErr().addErr(newTagError("First", "eq||eq||one_of", &OrErr{
+				Sl: []error{
+					newTagError("", eqTag, errors.New("e is not equal to a")),
+					newTagError("", eqTag, errors.New("e is not equal to b")),
+					newTagError("", oneofTag, errors.New("must have at least one of [c d]")),
+				},
+			})),
+		},
+		{
+			name: "negated tag fails, should not error",
+			s:    &mock2{First: "user"},
+			want: nil,
+		},
+		{
+			name: "negated tag passes, should error",
+			s:    &mock2{First: "root"},
+			want: newAggErr().addErr(newTagError("First", "not(one_of)", errors.New("must not pass 'one_of' tag"))),
+		},
+		{
+			name: "alternative points to another field, should not error",
+			s:    &mock3{First: 10, Second: 5},
+			want: nil,
+		},
+		{
+			name: "alternative points to another field, should error",
+			s:    &mock3{First: 11, Second: 5},
+			want: newAggErr().addErr(newTagError("First", "max||eq", &OrErr{
+				Sl: []error{
+					newTagError("", maxTag, errors.New("11 is more than 5")),
+					newTagError("", eqTag, errors.New("11 is not equal to 10")),
+				},
+			})),
+		},
+		{
+			name: "negated alias fails, should not error",
+			s:    &mock4{First: "user"},
+			want: nil,
+		},
+		{
+			name: "negated alias passes but the alternative passes, should not error",
+			s:    &mock4{First: "root"},
+			want: nil,
+		},
+		{
+			name: "negated alias passes, should error",
+			s:    &mock4{First: "admin"},
+			want: newAggErr().addErr(newTagError("First", "not(reserved)||eq", &OrErr{
+				Sl: []error{
+					newTagError("", "not(reserved)", errors.New("must not pass 'reserved' tag")),
+					newTagError("", eqTag, errors.New("admin is not equal to root")),
+				},
+			})),
+		},
+		{
+			name: "negated required doesn't conflict with optional, should error",
+			s:    &mock5{First: "a"},
+			want: newAggErr().addErr(newTagError("First", "not(required)", errors.New("must not pass 'required' tag"))),
+		},
+		{
+			name: "skipping alternative passes, should skip the tags after the group",
+			s:    &mock6{},
+			want: nil,
+		},
+		{
+			name: "alternative passes without a skip, should validate the tags after the group",
+			s:    &mock6{First: "ab"},
+			want: newAggErr().addErr(newTagError("First", minTag, errors.New("ab is less than 3"))),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := v.Validate(tt.s); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Vali.Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComposeSyntax(t *testing.T) {
+	v := New()
+	v.SetTagResultValidation("stop", func(s interface{}, o []interface{}) Result {
+		return Result{Skip: true}
+	})
+
+	tests := []struct {
+		name string
+		tag  string
+		want error
+	}{
+		{
+			name: "not wraps an OR group, should error",
+			tag:  "not(eq=a||eq=b)",
+			want: newAggErr().addErr(errors.New("'not(eq=a||eq=b)' can't negate an OR group, negate every alternative instead")),
+		},
+		{
+			name: "not is not closed, should error",
+			tag:  "not(eq=a",
+			want: newAggErr().addErr(errors.New("tag 'not(eq=a' is missing the closing ')'")),
+		},
+		{
+			name: "alternatives have different severities, should error",
+			tag:  "eq=a||warn:eq=b",
+			want: newAggErr().addErr(errors.New("alternatives of 'eq||eq' have different severities, every alternative needs the same 'warn:' prefix")),
+		},
+		{
+			name: "alternatives have different groups, should error",
+			tag:  "eq@create=a||eq=b",
+			want: newAggErr().addErr(errors.New("alternatives of 'eq||eq' have different groups, every alternative needs the same groups")),
+		},
+		{
+			name: "alternatives have the same severity, should not error",
+			tag:  "warn:eq=a||warn:eq=b",
+			want: nil,
+		},
+		{
+			name: "negated tag skips, should skip the remaining tags",
+			tag:  "not(stop)|eq=b",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := v.Var("c", tt.tag); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Vali.Var() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("struct field wraps an OR group with not, should error", func(t *testing.T) {
+		s := &struct {
+			First string `vali:"not(eq=a||eq=b)"`
+		}{}
+		want := newAggErr().addErr(errors.New("'not(eq=a||eq=b)' can't negate an OR group, negate every alternative instead"))
+		if got := v.Validate(s); !reflect.DeepEqual(got, want) {
+			t.Errorf("Vali.Validate() = %v, want %v", got, want)
+		}
+	})
+}
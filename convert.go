@@ -0,0 +1,105 @@
+package vali
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// setValue parses the given values in to the type of `fv` and sets it.
+// Slices and arrays get an element for every value, while other types
+// get the values joined by `valueSep`. Nil pointers are allocated.
+// Types implementing `encoding.TextUnmarshaler` are supported,
+// which includes `time.Time` in the RFC 3339 format.
+func setValue(fv reflect.Value, vals []string) error {
+	if fv.Kind() == reflect.Ptr {
+		if !fv.IsNil() {
+			return setValue(fv.Elem(), vals)
+		}
+		// A nil pointer stays nil if the values can't be set
+		ptr := reflect.New(fv.Type().Elem())
+		if err := setValue(ptr.Elem(), vals); err != nil {
+			return err
+		}
+		fv.Set(ptr)
+		return nil
+	}
+
+	if reflect.PtrTo(fv.Type()).Implements(textUnmarshalerType) && fv.CanAddr() {
+		tu := fv.Addr().Interface().(encoding.TextUnmarshaler)
+		return tu.UnmarshalText([]byte(strings.Join(vals, valueSep)))
+	}
+
+	switch fv.Kind() {
+	case reflect.Slice:
+		sl := reflect.MakeSlice(fv.Type(), len(vals), len(vals))
+		for i, val := range vals {
+			if err := setValue(sl.Index(i), []string{val}); err != nil {
+				return err
+			}
+		}
+		fv.Set(sl)
+		return nil
+	case reflect.Array:
+		if len(vals) > fv.Len() {
+			return fmt.Errorf("%d values don't fit in to %s", len(vals), fv.Type())
+		}
+		for i, val := range vals {
+			if err := setValue(fv.Index(i), []string{val}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	s := strings.Join(vals, valueSep)
+	if fv.Type() == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		fv.SetInt(int64(d))
+		return nil
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		fv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetFloat(f)
+	default:
+		return fmt.Errorf("can't set a value of type %s", fv.Type())
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package vali
+
+import (
+	"errors"
+	"reflect"
+)
+
+// depthTag is the tag of the errors returned for the nested
+// structs which are deeper than `ValidateOptions.MaxDepth`.
+const depthTag = "depth"
+
+// ErrMaxDepth is the error of the nested structs that are not validated
+// because they're deeper than `ValidateOptions.MaxDepth`.
+var ErrMaxDepth = errors.New("max depth of nested structs is exceeded")
+
+// visits holds the structs on the path from the validated struct
+// to the struct that is being validated, it's used to detect cycles.
+type visits map[visit]struct{}
+
+// visit identifies a struct by its address, the type is a part of it
+// as a struct has the same address as its first field.
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// enter adds the struct to the path, it reports false if the struct is already on it.
+// Structs that are not addressable can't be a part of a cycle, so they're always entered.
+func (vs visits) enter(val reflect.Value) bool {
+	if !val.CanAddr() {
+		return true
+	}
+
+	key := visit{ptr: val.UnsafeAddr(), typ: val.Type()}
+	if _, ok := vs[key]; ok {
+		return false
+	}
+	vs[key] = struct{}{}
+	return true
+}
+
+// leave removes the struct from the path.
+func (vs visits) leave(val reflect.Value) {
+	if val.CanAddr() {
+		delete(vs, visit{ptr: val.UnsafeAddr(), typ: val.Type()})
+	}
+}
+
+// hasValidations reports whether validating the struct type checks anything,
+// so leaf structs such as `time.Time` don't count towards `ValidateOptions.MaxDepth`.
+func (v *Vali) hasValidations(ps *plans, typ reflect.Type) bool {
+	if !isNestedStruct(typ) {
+		return false
+	}
+	if _, ok := v.types[typ]; ok {
+		return true
+	}
+
+	p := v.plan(ps, typ)
+	if len(p.fields) > 0 {
+		return true
+	}
+	// Flattened embedded structs have their type validations run with the struct
+	for _, index := range p.embeds {
+		if _, ok := v.types[derefType(typ.FieldByIndex(index).Type)]; ok {
+			return true
+		}
+	}
+	return false
+}
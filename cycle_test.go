@@ -0,0 +1,170 @@
+package vali
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type cycleNode struct {
+	Name   string     `vali:"required"`
+	Kind   string     `vali:"default=node"`
+	Parent *cycleNode `vali:"optional"`
+	Next   *cycleNode `vali:"optional"`
+}
+
+func TestValidateCycles(t *testing.T) {
+	self := &cycleNode{}
+	self.Next = self
+
+	child := &cycleNode{}
+	parent := &cycleNode{Name: "a", Next: child}
+	child.Parent = parent
+
+	shared := &cycleNode{}
+
+	tests := []struct {
+		name string
+		s    *cycleNode
+		want error
+	}{
+		{
+			name: "struct points to itself, should validate it once",
+			s:    self,
+			want: newAggErr().addErr(newTagError("Name", requiredTag, errors.New("empty string"))),
+		},
+		{
+			name: "child points back to its parent, should skip the parent",
+			s:    parent,
+			want: newAggErr().addErr(
+				newAggErr().addErr(withPath(newTagError("Name", requiredTag, errors.New("empty string")), "Next.Name")),
+			),
+		},
+		{
+			name: "same struct without a cycle, should validate it on every path",
+			s:    &cycleNode{Name: "a", Parent: shared, Next: shared},
+			want: newAggErr().addErr(
+				newAggErr().addErr(withPath(newTagError("Name", requiredTag, errors.New("empty string")), "Parent.Name")),
+				newAggErr().addErr(withPath(newTagError("Name", requiredTag, errors.New("empty string")), "Next.Name")),
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := New().Validate(tt.s); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Vali.Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("defaults of a cycle, should be applied once", func(t *testing.T) {
+		s := &cycleNode{Name: "a"}
+		s.Next = s
+		if err := New().ApplyDefaults(s); err != nil {
+			t.Fatalf("Vali.ApplyDefaults() = %v", err)
+		}
+		if s.Kind != "node" {
+			t.Errorf("Vali.ApplyDefaults() Kind = %v, want node", s.Kind)
+		}
+	})
+}
+
+func TestValidateMaxDepth(t *testing.T) {
+	s := &cycleNode{Name: "a", Next: &cycleNode{Name: "b", Next: &cycleNode{}}}
+
+	tests := []struct {
+		name     string
+		maxDepth int
+		want     error
+	}{
+		{
+			name:     "no limit, should validate every struct",
+			maxDepth: 0,
+			want: newAggErr().addErr(newAggErr().addErr(newAggErr().addErr(
+				withPath(newTagError("Name", requiredTag, errors.New("empty string")), "Next.Next.Name"),
+			))),
+		},
+		{
+			name:     "limit is deeper than the structs, should validate every struct",
+			maxDepth: 3,
+			want: newAggErr().addErr(newAggErr().addErr(newAggErr().addErr(
+				withPath(newTagError("Name", requiredTag, errors.New("empty string")), "Next.Next.Name"),
+			))),
+		},
+		{
+			name:     "limit is exceeded, should error with the path of the struct",
+			maxDepth: 2,
+			want: newAggErr().addErr(newAggErr().addErr(
+				&FieldError{Field: "Next", Path: "Next.Next", Tag: depthTag, Err: ErrMaxDepth},
+			)),
+		},
+		{
+			name:     "limit of one, should only validate the struct itself",
+			maxDepth: 1,
+			want: newAggErr().addErr(
+				&FieldError{Field: "Next", Path: "Next", Tag: depthTag, Err: ErrMaxDepth},
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := New().ValidateWithOptions(s, ValidateOptions{MaxDepth: tt.maxDepth})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Vali.ValidateWithOptions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type depthLeaf struct {
+	Note string
+}
+
+type depthTyped struct {
+	Note string
+}
+
+type depthEvent struct {
+	At     time.Time       `vali:"required"`
+	Hidden struct{ n int } `vali:"optional"`
+	Leaf   depthLeaf       `vali:"optional"`
+	Typed  depthTyped      `vali:"optional"`
+}
+
+func TestValidateMaxDepthLeafStructs(t *testing.T) {
+	tests := []struct {
+		name  string
+		typed bool
+		want  error
+	}{
+		{
+			name:  "structs have nothing to validate, should not error",
+			typed: false,
+			want:  nil,
+		},
+		{
+			name:  "struct has a type validation, should error",
+			typed: true,
+			want:  newAggErr().addErr(&FieldError{Field: "Typed", Path: "Typed", Tag: depthTag, Err: ErrMaxDepth}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := New()
+			if tt.typed {
+				v.SetTypeValidation(depthTyped{}, func(s interface{}) error {
+					return nil
+				})
+			}
+
+			got := v.ValidateWithOptions(&depthEvent{At: time.Now()}, ValidateOptions{MaxDepth: 1})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Vali.ValidateWithOptions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package vali
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ApplyDefaults sets the values of the `default` tags
+// to the zero valued fields of the given struct and its nested structs,
+// without validating them. Nil pointers to structs are only allocated if
+// they have a `default` tag, which can be used without a value.
+//
+// `Validate` applies the defaults as well, but it only descends in to
+// the nested structs of the fields that have tags, while this method
+// descends in to every nested struct. It can be used when the defaults
+// are needed before the struct can be validated or for the nested structs
+// that are not validated.
+// Example:
+/*
+
+	type Config struct {
+		Addr    string        `vali:"default=:8080"`
+		Timeout time.Duration `vali:"default=5s"`
+		Hosts   []string      `vali:"default=a,b"`
+		TLS     *TLSConfig    `vali:"default"`
+	}
+
+	err := v.ApplyDefaults(&cfg)
+
+*/
+func (v *Vali) ApplyDefaults(s interface{}) error {
+	errs := newAggErr()
+	if s == nil {
+		return errs.addErr(errors.New("struct is nil"))
+	}
+
+	val := reflect.ValueOf(s)
+	if val.Kind() != reflect.Ptr {
+		return errs.addErr(fmt.Errorf("function only accepts pointer to structs; got %s", val.Kind()))
+	}
+
+	val, _ = derefReflectValue(val)
+	if val.Kind() != reflect.Struct {
+		return errs.addErr(fmt.Errorf("function only accepts structs; got %s", val.Kind()))
+	}
+
+	v.applyDefaults(v.loadPlans(), val, "", visits{}, errs)
+	return errs.toError()
+}
+
+func (v *Vali) applyDefaults(ps *plans, val reflect.Value, path string, vs visits, errs *AggErr) {
+	// The struct was reached through a cycle of pointers
+	if !vs.enter(val) {
+		return
+	}
+	defer vs.leave(val)
+
+	p := v.plan(ps, val.Type())
+	ref := structRef(val, p)
+	for _, fp := range p.fields {
+		fv, ok := fieldByIndex(val, fp.index)
+		if fp.err != nil || !ok || !fv.CanSet() {
+			continue
+		}
+
+		tags := resolveTags(activeTags(fp.tags, nil), ref)
+		if err := applyDefault(fp.field.Name, joinPath(path, fp.field.Name), fv, tags); err != nil {
+			errs.addErr(err)
+		}
+	}
+
+	for _, f := range p.visible {
+		fv, ok := fieldByIndex(val, f.Index)
+		if !ok || !fv.CanSet() {
+			continue
+		}
+
+		if derf, ok := derefReflectValue(fv); ok && derf.Kind() == reflect.Struct {
+			nested := newAggErr()
+			v.applyDefaults(ps, derf, joinPath(path, f.Name), vs, nested)
+			if err := nested.toError(); err != nil {
+				errs.addErr(err)
+			}
+		}
+	}
+}
+
+// applyDefault sets the value of the `default` tag
+// to the field at the given path if it has one and the field is zero valued.
+func applyDefault(field, path string, fv reflect.Value, tags []tag) error {
+	for _, t := range tags {
+		if t.name != defaultTag || t.negate || len(t.alts) > 0 {
+			continue
+		}
+		if !fv.CanSet() || !fv.IsZero() {
+			return nil
+		}
+
+		vals := make([]string, 0, len(t.args))
+		for _, arg := range t.args {
+			vals = append(vals, GetString(arg))
+		}
+
+		// A nil pointer to a struct is only allocated,
+		// its fields get their own defaults.
+		if len(vals) == 0 && fv.Kind() == reflect.Ptr {
+			fv.Set(reflect.New(fv.Type().Elem()))
+			return nil
+		}
+
+		if err := setValue(fv, vals); err != nil {
+			return t.fieldError(field, path, err)
+		}
+		return nil
+	}
+
+	return nil
+}
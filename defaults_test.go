@@ -0,0 +1,176 @@
+package vali
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestApplyDefaults(t *testing.T) {
+	type TLS struct {
+		Cert string `vali:"default=cert.pem"`
+	}
+	type Config struct {
+		Addr    string        `vali:"default=:8080"`
+		Port    int           `vali:"default=80"`
+		Ratio   float64       `vali:"default=0.5"`
+		Debug   bool          `vali:"default=true"`
+		Timeout time.Duration `vali:"default=5s"`
+		Hosts   []string      `vali:"default=a,b"`
+		Retries *uint         `vali:"default=3"`
+		Since   time.Time     `vali:"default=2020-01-02T15:04:05Z"`
+		TLS     *TLS          `vali:"default"`
+		Nested  TLS
+		Skipped *TLS
+	}
+	three := uint(3)
+
+	tests := []struct {
+		name string
+		s    interface{}
+		want interface{}
+	}{
+		{
+			name: "zero valued struct, should set all defaults",
+			s:    &Config{},
+			want: &Config{
+				Addr:    ":8080",
+				Port:    80,
+				Ratio:   0.5,
+				Debug:   true,
+				Timeout: 5 * time.Second,
+				Hosts:   []string{"a", "b"},
+				Retries: &three,
+				Since:   time.Date(2020, 1, 2, 15, 4, 5, 0, time.UTC),
+				TLS:     &TLS{Cert: "cert.pem"},
+				Nested:  TLS{Cert: "cert.pem"},
+			},
+		},
+		{
+			name: "struct has values, should only set the zero valued fields",
+			s: &Config{
+				Addr:  ":9090",
+				Hosts: []string{"c"},
+				TLS:   &TLS{Cert: "other.pem"},
+			},
+			want: &Config{
+				Addr:    ":9090",
+				Port:    80,
+				Ratio:   0.5,
+				Debug:   true,
+				Timeout: 5 * time.Second,
+				Hosts:   []string{"c"},
+				Retries: &three,
+				Since:   time.Date(2020, 1, 2, 15, 4, 5, 0, time.UTC),
+				TLS:     &TLS{Cert: "other.pem"},
+				Nested:  TLS{Cert: "cert.pem"},
+			},
+		},
+	}
+
+	v := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := v.ApplyDefaults(tt.s); err != nil {
+				t.Fatalf("Vali.ApplyDefaults() error = %v", err)
+			}
+			if !reflect.DeepEqual(tt.s, tt.want) {
+				t.Errorf("Vali.ApplyDefaults() = %+v, want %+v", tt.s, tt.want)
+			}
+		})
+	}
+
+	t.Run("default can't be parsed, should error", func(t *testing.T) {
+		type mock struct {
+			First int `vali:"default=a"`
+		}
+		_, perr := strconv.ParseInt("a", 10, 64)
+		want := newAggErr().addErr(newTagError("First", defaultTag, perr))
+		if got := v.ApplyDefaults(&mock{}); !reflect.DeepEqual(got, want) {
+			t.Errorf("Vali.ApplyDefaults() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("default of a nil pointer can't be parsed, should keep the pointer nil", func(t *testing.T) {
+		type mock struct {
+			First *int `vali:"default=a"`
+		}
+		s := &mock{}
+		if err := v.ApplyDefaults(s); err == nil {
+			t.Error("Vali.ApplyDefaults() expected an error")
+		}
+		if s.First != nil {
+			t.Errorf("Vali.ApplyDefaults() set First to %v, want nil", *s.First)
+		}
+	})
+
+	t.Run("string defaults look like numbers, should be kept as written", func(t *testing.T) {
+		type mock struct {
+			Version string   `vali:"default=1.0"`
+			Code    string   `vali:"default=007"`
+			Size    string   `vali:"default=1e3"`
+			Ratio   float64  `vali:"default=1e3"`
+			Codes   []string `vali:"default=01,02"`
+		}
+		s := &mock{}
+		if err := v.ApplyDefaults(s); err != nil {
+			t.Fatalf("Vali.ApplyDefaults() error = %v", err)
+		}
+		want := &mock{Version: "1.0", Code: "007", Size: "1e3", Ratio: 1000, Codes: []string{"01", "02"}}
+		if !reflect.DeepEqual(s, want) {
+			t.Errorf("Vali.ApplyDefaults() = %+v, want %+v", s, want)
+		}
+	})
+
+	t.Run("not a pointer, should error", func(t *testing.T) {
+		if err := v.ApplyDefaults(Config{}); err == nil {
+			t.Error("Vali.ApplyDefaults() expected an error")
+		}
+	})
+}
+
+func TestValidateDefaults(t *testing.T) {
+	type mock struct {
+		Timeout time.Duration `vali:"default=5s"`
+		Port    int           `vali:"default=80|max=10"`
+		Name    string        `vali:"required|default=a|eq=a"`
+	}
+
+	s := &mock{}
+	want := newAggErr().addErr(newTagError("Port", maxTag, errors.New("80 is more than 10")))
+	if got := New().Validate(s); !reflect.DeepEqual(got, want) {
+		t.Errorf("Vali.Validate() = %v, want %v", got, want)
+	}
+	if s.Timeout != 5*time.Second || s.Port != 80 || s.Name != "a" {
+		t.Errorf("Vali.Validate() should set the defaults, got %+v", s)
+	}
+}
+
+func TestDefaultsNestedStructs(t *testing.T) {
+	type inner struct {
+		Name string `vali:"default=a"`
+	}
+	type mock struct {
+		Tagged   inner `vali:"optional"`
+		Untagged inner
+	}
+
+	validated := &mock{}
+	if err := New().Validate(validated); err != nil {
+		t.Fatalf("Vali.Validate() error = %v", err)
+	}
+	// Validate only descends in to the nested structs of the tagged fields
+	if want := (&mock{Tagged: inner{Name: "a"}}); !reflect.DeepEqual(validated, want) {
+		t.Errorf("Vali.Validate() = %+v, want %+v", validated, want)
+	}
+
+	applied := &mock{}
+	if err := New().ApplyDefaults(applied); err != nil {
+		t.Fatalf("Vali.ApplyDefaults() error = %v", err)
+	}
+	if want := (&mock{Tagged: inner{Name: "a"}, Untagged: inner{Name: "a"}}); !reflect.DeepEqual(applied, want) {
+		t.Errorf("Vali.ApplyDefaults() = %+v, want %+v", applied, want)
+	}
+}
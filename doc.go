@@ -21,6 +21,7 @@
 // Tags are seperated by `|`
 // Tag values by `,`
 // There are special tags: `*` to point to another struct field and `>` to validate slice elements
+// Tags can be joined in to OR groups by `||` and inverted by wrapping them in `not(...)`
 //
 // Example tag: `vali:"min=2|>|one_of=a,b"`. It will validate that a given slice is
 // longer than 2 elements and that it's values are either `a` or `b` strings.
@@ -0,0 +1,183 @@
+package vali
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+)
+
+const (
+	envKey = "env"
+	// envSepKey is the struct tag holding the separator of slice values, `,` is used if it's not set.
+	envSepKey = "envSeparator"
+	envJoin   = "_"
+)
+
+// LoadEnv sets the fields of the struct to the environment variables named by their `env` tag
+// and validates it. The names are joined to the prefix with a `_`, nested structs with an `env` tag
+// add it to the prefix of their fields. Slices are split by `,` or by the `envSeparator` tag,
+// durations are parsed with `time.ParseDuration` and `default` tags are applied to the fields
+// which variables are not set. The errors of the fields with an `env` tag have the
+// name of the variable as their field name, variables that can't be converted to the
+// type of their field are returned as a `FieldError` with the `type` tag.
+// Example:
+/*
+
+	type Config struct {
+		Port    int           `env:"PORT" vali:"default=8080|min=0|max=65535"`
+		Timeout time.Duration `env:"TIMEOUT" vali:"default=5s"`
+		Hosts   []string      `env:"HOSTS" envSeparator:";" vali:"required"`
+		DB      DBConfig      `env:"DB"`
+	}
+
+	// APP_PORT, APP_TIMEOUT, APP_HOSTS, APP_DB_USER ...
+	err := v.LoadEnv(&cfg, "APP")
+
+*/
+func (v *Vali) LoadEnv(s interface{}, prefix string) error {
+	return v.loadEnv(s, prefix, os.LookupEnv)
+}
+
+func (v *Vali) loadEnv(s interface{}, prefix string, lookup func(string) (string, bool)) error {
+	errs := newAggErr()
+	if s == nil {
+		return errs.addErr(errors.New("struct is nil"))
+	}
+
+	val := reflect.ValueOf(s)
+	if val.Kind() != reflect.Ptr {
+		return errs.addErr(fmt.Errorf("function only accepts pointer to structs; got %s", val.Kind()))
+	}
+
+	val, _ = derefReflectValue(val)
+	if val.Kind() != reflect.Struct {
+		return errs.addErr(fmt.Errorf("function only accepts structs; got %s", val.Kind()))
+	}
+
+	l := &envLoader{v: v, lookup: lookup, names: map[string]string{}, errs: errs}
+	l.load(val, strings.TrimSuffix(prefix, envJoin), "")
+
+	st := newState(ValidateOptions{})
+	if len(l.failed) > 0 {
+		st.filter = newFilter(l.failed, true, nil)
+	}
+	if err := v.validate(s, st); err != nil {
+		l.rename(err)
+		if agg, ok := err.(*AggErr); ok {
+			errs.addErr(agg.Sl...)
+		} else {
+			errs.addErr(err)
+		}
+	}
+	return errs.toError()
+}
+
+// envLoader sets the fields of a struct to the environment variables.
+type envLoader struct {
+	v      *Vali
+	lookup func(string) (string, bool)
+	// names holds the names of the variables by the paths of their fields.
+	names map[string]string
+	// failed holds the paths of the fields which variables couldn't be converted.
+	failed []string
+	errs   *AggErr
+}
+
+// load sets the fields of the struct, it reports whether any of the variables were set.
+func (l *envLoader) load(val reflect.Value, prefix, path string) bool {
+	found := false
+	for i := 0; i < val.NumField(); i++ {
+		sf := val.Type().Field(i)
+		fv := val.Field(i)
+		name, ok := sf.Tag.Lookup(envKey)
+		// Flattened embedded structs keep the path of the struct,
+		// the fields of unexported ones can still be set.
+		flat := l.v.flattens(sf)
+		if (!fv.CanSet() && !(flat && fv.Kind() == reflect.Struct)) || name == "-" {
+			continue
+		}
+
+		fpath := joinPath(path, sf.Name)
+		if flat {
+			fpath = path
+		}
+		if isNestedStruct(sf.Type) {
+			nprefix := prefix
+			if ok {
+				nprefix = envName(prefix, name)
+				if !flat {
+					l.names[fpath] = nprefix
+				}
+			}
+			// Nil pointers are only allocated if any of their variables are set
+			if fv.Kind() == reflect.Ptr && fv.IsNil() {
+				nested := reflect.New(sf.Type.Elem())
+				if l.load(nested.Elem(), nprefix, fpath) {
+					fv.Set(nested)
+					found = true
+				}
+				continue
+			}
+			derf, _ := derefReflectValue(fv)
+			found = l.load(derf, nprefix, fpath) || found
+			continue
+		}
+		if !ok || name == "" {
+			continue
+		}
+
+		name = envName(prefix, name)
+		l.names[fpath] = name
+		raw, ok := l.lookup(name)
+		if !ok {
+			continue
+		}
+		found = true
+
+		vals := []string{raw}
+		if k := derefType(sf.Type).Kind(); k == reflect.Slice || k == reflect.Array {
+			sep := sf.Tag.Get(envSepKey)
+			if sep == "" {
+				sep = valueSep
+			}
+			vals = strings.Split(raw, sep)
+		}
+		if err := setValue(fv, vals); err != nil {
+			l.errs.addErr(&FieldError{
+				Field: name,
+				Path:  fpath,
+				Tag:   typeTag,
+				Err:   fmt.Errorf("%s can't be converted to %s", raw, sf.Type),
+			})
+			l.failed = append(l.failed, fpath)
+		}
+	}
+	return found
+}
+
+// rename replaces the field names of the errors with the names of their variables.
+func (l *envLoader) rename(err error) {
+	switch e := err.(type) {
+	case *AggErr:
+		for _, err := range e.Sl {
+			l.rename(err)
+		}
+	case *FieldError:
+		path := e.Path
+		if i := strings.IndexByte(path, '['); i >= 0 {
+			path = path[:i]
+		}
+		if name, ok := l.names[path]; ok {
+			e.Field = name
+		}
+	}
+}
+
+func envName(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + envJoin + name
+}
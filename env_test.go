@@ -0,0 +1,160 @@
+package vali
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type envDB struct {
+	User string `env:"USER" vali:"required"`
+	Pass string `env:"PASS"`
+}
+
+type envTLS struct {
+	Cert string `env:"CERT" vali:"required"`
+}
+
+type envConfig struct {
+	Port    int           `env:"PORT" vali:"default=8080|min=0|max=65535"`
+	Timeout time.Duration `env:"TIMEOUT" vali:"default=5s"`
+	Hosts   []string      `env:"HOSTS" envSeparator:";" vali:"required|>|one_of=a,b"`
+	Tags    []string      `env:"TAGS"`
+	Name    string        `vali:"optional|max=3"`
+	Skip    string        `env:"-"`
+	DB      *envDB        `env:"DB" vali:"required"`
+	TLS     *envTLS       `env:"TLS" vali:"optional"`
+}
+
+type envEmbedded struct {
+	envDB
+	envTLS `env:"TLS"`
+}
+
+func TestLoadEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       interface{}
+		prefix  string
+		env     map[string]string
+		want    interface{}
+		wantErr error
+	}{
+		{
+			name:   "variables are set, should load them",
+			s:      &envConfig{},
+			prefix: "APP_",
+			env: map[string]string{
+				"APP_PORT":     "80",
+				"APP_TIMEOUT":  "1m",
+				"APP_HOSTS":    "a;b",
+				"APP_TAGS":     "x,y",
+				"APP_Skip":     "a",
+				"APP_DB_USER":  "root",
+				"APP_TLS_CERT": "c",
+			},
+			want: &envConfig{
+				Port:    80,
+				Timeout: time.Minute,
+				Hosts:   []string{"a", "b"},
+				Tags:    []string{"x", "y"},
+				DB:      &envDB{User: "root"},
+				TLS:     &envTLS{Cert: "c"},
+			},
+		},
+		{
+			name:   "variables are missing, should apply the defaults and report the variable names",
+			s:      &envConfig{Name: "abcd"},
+			prefix: "APP",
+			env:    map[string]string{},
+			want:   &envConfig{Port: 8080, Timeout: 5 * time.Second, Name: "abcd"},
+			wantErr: newAggErr().addErr(
+				withPath(newTagError("APP_HOSTS", requiredTag, errors.New("[]string is nil")), "Hosts"),
+				withPath(newTagError("Name", maxTag, errors.New("abcd is more than 3")), "Name"),
+				withPath(newTagError("APP_DB", requiredTag, errors.New("value is nil")), "DB"),
+			),
+		},
+		{
+			name:   "variables can't be converted, should report them with the type tag",
+			s:      &envConfig{},
+			prefix: "",
+			env:    map[string]string{"PORT": "http", "TIMEOUT": "5", "HOSTS": "a,c", "DB_USER": "root"},
+			want:   &envConfig{Hosts: []string{"a,c"}, DB: &envDB{User: "root"}},
+			wantErr: newAggErr().addErr(
+				withPath(newTagError("PORT", typeTag, errors.New("http can't be converted to int")), "Port"),
+				withPath(newTagError("TIMEOUT", typeTag, errors.New("5 can't be converted to time.Duration")), "Timeout"),
+				withPath(newTagError("HOSTS", oneofTag, errors.New("must have at least one of [a b]")), "Hosts[0]"),
+			),
+		},
+		{
+			name:   "nested field fails, should report the variable name with the nested prefix",
+			s:      &envConfig{},
+			prefix: "APP",
+			env:    map[string]string{"APP_HOSTS": "a", "APP_DB_PASS": "p"},
+			want:   &envConfig{Port: 8080, Timeout: 5 * time.Second, Hosts: []string{"a"}, DB: &envDB{Pass: "p"}},
+			wantErr: newAggErr().addErr(
+				newAggErr().addErr(withPath(newTagError("APP_DB_USER", requiredTag, errors.New("empty string")), "DB.User")),
+			),
+		},
+		{
+			name:   "embedded structs, should load their fields as fields of the struct",
+			s:      &envEmbedded{},
+			prefix: "APP",
+			env:    map[string]string{"APP_PASS": "p", "APP_TLS_CERT": "c"},
+			want:   &envEmbedded{envDB: envDB{Pass: "p"}, envTLS: envTLS{Cert: "c"}},
+			wantErr: newAggErr().addErr(
+				withPath(newTagError("APP_USER", requiredTag, errors.New("empty string")), "User"),
+			),
+		},
+		{
+			name:    "not a pointer, should error",
+			s:       envConfig{},
+			env:     map[string]string{},
+			want:    envConfig{},
+			wantErr: newAggErr().addErr(errors.New("function only accepts pointer to structs; got struct")),
+		},
+	}
+
+	v := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lookup := func(k string) (string, bool) {
+				val, ok := tt.env[k]
+				return val, ok
+			}
+			if err := v.loadEnv(tt.s, tt.prefix, lookup); !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("Vali.LoadEnv() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(tt.s, tt.want) {
+				t.Errorf("Vali.LoadEnv() = %+v, want %+v", tt.s, tt.want)
+			}
+		})
+	}
+
+	t.Run("variable is missing, should keep the string default as written", func(t *testing.T) {
+		type mock struct {
+			Version string `env:"VERSION" vali:"default=1.0"`
+		}
+		s := &mock{}
+		lookup := func(string) (string, bool) { return "", false }
+		if err := v.loadEnv(s, "APP", lookup); err != nil {
+			t.Fatalf("Vali.LoadEnv() error = %v", err)
+		}
+		if s.Version != "1.0" {
+			t.Errorf("Vali.LoadEnv() Version = %v, want 1.0", s.Version)
+		}
+	})
+
+	t.Run("environment variable is set, should load it", func(t *testing.T) {
+		t.Setenv("VALI_TEST_DB_USER", "root")
+		t.Setenv("VALI_TEST_HOSTS", "a")
+		var cfg envConfig
+		if err := v.LoadEnv(&cfg, "VALI_TEST"); err != nil {
+			t.Fatalf("Vali.LoadEnv() error = %v", err)
+		}
+		if cfg.DB.User != "root" {
+			t.Errorf("Vali.LoadEnv() DB.User = %v, want root", cfg.DB.User)
+		}
+	})
+}
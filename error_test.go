@@ -2,6 +2,8 @@ package vali
 
 import (
 	"errors"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -51,3 +53,51 @@ c`,
 		})
 	}
 }
+
+type pathBase struct {
+	Nick string `json:"nick"`
+}
+
+type pathAddress struct {
+	City string `json:"city"`
+}
+
+type pathUser struct {
+	pathBase
+	Address *pathAddress     `json:"address"`
+	Tags    []string         `json:"tags"`
+	Grid    [][]*pathAddress `json:"grid"`
+	Any     interface{}      `json:"any"`
+	Plain   string
+}
+
+func TestFieldError_PathBy(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want []string
+	}{
+		{name: "empty path, should have no elements", path: "", want: []string{}},
+		{name: "nested field, should use the names", path: "Address.City", want: []string{"address", "city"}},
+		{name: "dive element, should keep the index", path: "Tags[1]", want: []string{"tags", "[1]"}},
+		{name: "field of a nested dive element, should use the names", path: "Grid[1][0].City", want: []string{"grid", "[1]", "[0]", "city"}},
+		{name: "promoted field of an embedded struct, should use the name", path: "Nick", want: []string{"nick"}},
+		{name: "field of a struct held by an interface, should keep the name", path: "Any.City", want: []string{"any", "City"}},
+		{name: "unknown field, should keep the names", path: "Unknown.City", want: []string{"Unknown", "City"}},
+		{name: "field without a name, should use the field name", path: "Plain", want: []string{"Plain"}},
+	}
+	name := func(f reflect.StructField) string {
+		if n := strings.Split(f.Tag.Get("json"), ",")[0]; n != "" {
+			return n
+		}
+		return f.Name
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fe := &FieldError{Path: tt.path}
+			if got := fe.PathBy(reflect.TypeOf(&pathUser{}), name); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FieldError.PathBy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,5 @@
+package vali
+
+// ValidateReflect exposes the validation which never
+// uses the generated methods to the external tests.
+var ValidateReflect = (*Vali).validateReflect
@@ -0,0 +1,176 @@
+package vali
+
+import (
+	"reflect"
+	"sync"
+)
+
+// genTags holds the tags which valigen turns in to code,
+// the generated methods are not used if any of them are replaced.
+var genTags = map[string]struct{}{
+	requiredTag:        {},
+	requiredWithoutTag: {},
+	optionalTag:        {},
+	minTag:             {},
+	maxTag:             {},
+	eqTag:              {},
+	neqTag:             {},
+	oneofTag:           {},
+	noneofTag:          {},
+	dupsTag:            {},
+}
+
+// generated is implemented by the structs
+// which have validation methods generated by valigen.
+type generated interface {
+	ValidateValiWith(g *GenState) error
+}
+
+var (
+	defaultVali     *Vali
+	defaultValiOnce sync.Once
+)
+
+// DefaultVali returns the validator used by the `ValidateVali` methods generated by valigen,
+// the custom tags used by the generated methods have to be registered on it.
+func DefaultVali() *Vali {
+	defaultValiOnce.Do(func() {
+		defaultVali = New()
+	})
+	return defaultVali
+}
+
+// GenState is used by the validation methods generated by valigen,
+// it collects the errors of a single validation and validates the fields
+// which could not be generated the same way `Validate` does.
+type GenState struct {
+	v    *Vali
+	val  reflect.Value
+	ref  refFunc
+	plan *plan
+	st   *state
+	errs *AggErr
+	// err is set when the validation has to be aborted.
+	err error
+	// typed is set if the type validation was already run.
+	typed bool
+}
+
+// GenState returns the state of a new validation of the struct `s`,
+// which is validated by the methods generated by valigen.
+// Example:
+/*
+
+	err := user.ValidateValiWith(v.GenState(&user))
+
+*/
+func (v *Vali) GenState(s interface{}) *GenState {
+	val, _ := derefReflectValue(reflect.ValueOf(s))
+	st := newState(ValidateOptions{})
+	st.plans = v.loadPlans()
+	return v.genState(val, st)
+}
+
+func (v *Vali) genState(val reflect.Value, st *state) *GenState {
+	p := v.plan(st.plans, val.Type())
+	return &GenState{
+		v:    v,
+		val:  val,
+		ref:  structRef(val, p),
+		plan: p,
+		st:   st,
+		errs: newAggErr(),
+	}
+}
+
+// Type runs the type validation of the struct if one is set,
+// it reports whether the validation has to be stopped.
+func (g *GenState) Type() bool {
+	fn, ok := g.v.types[g.val.Type()]
+	if !ok || g.typed {
+		return false
+	}
+
+	s := g.val.Interface()
+	if g.val.CanAddr() {
+		s = g.val.Addr().Interface()
+	}
+	if err := fn(s); err != nil {
+		return g.Fail(err)
+	}
+	return false
+}
+
+// Fail adds the error of a generated field validation,
+// it reports whether the validation has to be stopped.
+func (g *GenState) Fail(err error) bool {
+	if e, ok := err.(*FieldError); ok {
+		e.Path = joinPath(g.st.errPath, e.Field)
+	}
+	g.st.addErr(g.errs, err)
+	return g.st.done()
+}
+
+// Field validates the field the same way `Validate` does,
+// it reports whether the validation has to be stopped.
+func (g *GenState) Field(name string) bool {
+	for _, fp := range g.plan.fields {
+		if fp.field.Name != name {
+			continue
+		}
+
+		done, err := g.v.validatePlanField(g.val, g.ref, fp, g.st, g.errs)
+		if err != nil {
+			g.err = err
+			return true
+		}
+		return done
+	}
+
+	return false
+}
+
+// Err returns the result of the validation.
+func (g *GenState) Err() error {
+	if g.err != nil {
+		return g.err
+	}
+	return g.errs.toError()
+}
+
+// validateReflect works the same as `Validate` but the generated
+// methods are never used, the generated code is tested against it.
+func (v *Vali) validateReflect(s interface{}) error {
+	st := newState(ValidateOptions{})
+	st.reflect = true
+	return v.validate(s, st)
+}
+
+// genMethods returns the generated validation methods of the struct,
+// if they can be used instead of validating it with reflection.
+func (v *Vali) genMethods(val reflect.Value, st *state) (generated, bool) {
+	if !val.CanAddr() || v.customized || v.tgName != valiTag {
+		return nil, false
+	}
+	if st.filter != nil || st.groups != nil || st.sanitize || st.reflect {
+		return nil, false
+	}
+	if len(v.rules[val.Type()]) > 0 || len(st.plans.loaded[val.Type()]) > 0 {
+		return nil, false
+	}
+	// valigen doesn't know about the promoted fields of embedded structs
+	if len(v.plan(st.plans, val.Type()).embeds) > 0 {
+		return nil, false
+	}
+
+	gen, ok := val.Addr().Interface().(generated)
+	return gen, ok
+}
+
+// customize marks the validator as customized
+// if the tag is one of the tags valigen turns in to code.
+func (v *Vali) customize(tag string) {
+	if _, ok := genTags[tag]; ok {
+		v.customized = true
+	}
+}
@@ -0,0 +1,120 @@
+package vali_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tomasmik/vali"
+	"github.com/tomasmik/vali/internal/gentest"
+)
+
+// The methods generated by valigen have to return the same
+// results as the validation which only uses reflection.
+func TestGeneratedParity(t *testing.T) {
+	v := vali.New()
+	// The generated `ValidateVali` uses the default validator
+	for _, rv := range []*vali.Vali{v, vali.DefaultVali()} {
+		if err := gentest.Register(rv); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name string
+		user func() gentest.User
+	}{
+		{
+			name: "valid",
+			user: gentest.ValidUser,
+		},
+		{
+			name: "zero valued",
+			user: func() gentest.User { return gentest.User{} },
+		},
+		{
+			name: "failing generated tags",
+			user: func() gentest.User {
+				u := gentest.ValidUser()
+				u.Name = "n"
+				u.Nick = "nickname"
+				u.Age = 150
+				u.Level = 4
+				u.Score = 1.5
+				u.Status = "old"
+				u.Role = "root"
+				u.Tags = []string{"a", "a"}
+				u.IDs = nil
+				u.Active = false
+				return u
+			},
+		},
+		{
+			name: "failing fields that are not generated",
+			user: func() gentest.User {
+				u := gentest.ValidUser()
+				u.Email = "email"
+				u.Login = "l"
+				u.Code = "c"
+				u.Address = &gentest.Address{City: "Riga"}
+				u.Backup = nil
+				u.Limit = 1
+				u.Items = []int{2, 1}
+				u.Kind = "admin"
+				u.Note = "long"
+				return u
+			},
+		},
+		{
+			name: "required without and optional skips",
+			user: func() gentest.User {
+				u := gentest.ValidUser()
+				u.Name = ""
+				u.Nick = ""
+				u.Age = 0
+				u.Tags = []string{}
+				return u
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reflective := tt.user()
+			want := vali.ValidateReflect(v, &reflective)
+
+			dispatched := tt.user()
+			if got := v.Validate(&dispatched); !reflect.DeepEqual(got, want) {
+				t.Errorf("Vali.Validate() = %v, want %v", got, want)
+			}
+			if !reflect.DeepEqual(dispatched, reflective) {
+				t.Errorf("Vali.Validate() set %+v, want %+v", dispatched, reflective)
+			}
+
+			generated := tt.user()
+			if got := generated.ValidateVali(); !reflect.DeepEqual(got, want) {
+				t.Errorf("User.ValidateVali() = %v, want %v", got, want)
+			}
+
+			report := tt.user()
+			wantReport := v.ValidateReport(&reflective)
+			if got := v.ValidateReport(&report); !reflect.DeepEqual(got, wantReport) {
+				t.Errorf("Vali.ValidateReport() = %v, want %v", got, wantReport)
+			}
+		})
+	}
+}
+
+func BenchmarkValidateGenerated(b *testing.B) {
+	v := vali.New()
+	a := gentest.Address{City: "Vilnius"}
+	for i := 0; i < b.N; i++ {
+		_ = v.Validate(&a)
+	}
+}
+
+func BenchmarkValidateReflection(b *testing.B) {
+	v := vali.New()
+	a := gentest.Address{City: "Vilnius"}
+	for i := 0; i < b.N; i++ {
+		_ = vali.ValidateReflect(v, &a)
+	}
+}
@@ -0,0 +1,80 @@
+package vali
+
+import (
+	"fmt"
+)
+
+// RegisterGroups registers validation groups which can then
+// be used in tags by adding `@` and the group name to the tag name.
+// Multiple groups can be joined with a `+`. Group names can only
+// contain letters, digits, `_` and `-`.
+// Tags with groups are only validated by `ValidateGroups` when one of
+// their groups is selected, while tags without groups are always validated.
+// Example:
+/*
+
+	v.RegisterGroups("create", "update")
+
+	type User struct {
+		ID   int    `vali:"required@update"`
+		Name string `vali:"required@create|optional@update|max@create+update=64"`
+	}
+
+*/
+func (v *Vali) RegisterGroups(groups ...string) {
+	for _, g := range groups {
+		if !isGroupName(g) {
+			continue
+		}
+		v.groups[g] = struct{}{}
+	}
+	v.resetPlans()
+}
+
+// ValidateGroups works the same as `Validate` but also
+// validates the tags that belong to one of the given groups.
+// Example:
+/*
+
+	err := v.ValidateGroups(user, "create")
+
+*/
+func (v *Vali) ValidateGroups(s interface{}, groups ...string) error {
+	st := newState(ValidateOptions{})
+	st.groups = make(map[string]struct{}, len(groups))
+	for _, g := range groups {
+		if _, ok := v.groups[g]; !ok {
+			return newAggErr().addErr(fmt.Errorf("unknown group '%s'", g))
+		}
+		st.groups[g] = struct{}{}
+	}
+
+	return v.validate(s, st)
+}
+
+// activeTags returns the tags that have to be validated
+// when the given groups are selected.
+// The given slice is never modified, a copy is returned if any tags were dropped.
+func activeTags(tgs []tag, groups map[string]struct{}) []tag {
+	active := make([]tag, 0, len(tgs))
+	for _, t := range tgs {
+		if t.inGroups(groups) {
+			active = append(active, t)
+		}
+	}
+	return active
+}
+
+// inGroups reports whether the tag has to be validated
+// when the given groups are selected.
+func (t tag) inGroups(groups map[string]struct{}) bool {
+	if len(t.groups) == 0 {
+		return true
+	}
+	for _, g := range t.groups {
+		if _, ok := groups[g]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+package vali
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestValidateGroups(t *testing.T) {
+	type mock struct {
+		ID   int    `vali:"required@update+admin"`
+		Name string `vali:"required@create|optional@update|max=5|min@admin=2"`
+		Mail string `vali:"optional|one_of=a@b"`
+	}
+	type mock2 struct {
+		ID int `vali:"required@unknown"`
+	}
+
+	v := New()
+	v.RegisterGroups("create", "update", "admin", "", "a.b")
+	if len(v.groups) != 3 {
+		t.Errorf("expected to find 3 groups, found: %d", len(v.groups))
+	}
+
+	tests := []struct {
+		name   string
+		s      interface{}
+		groups []string
+		want   error
+	}{
+		{
+			name:   "no groups selected, should only validate tags without groups",
+			s:      &mock{Name: "abcdef"},
+			groups: nil,
+			want:   newAggErr().addErr(newTagError("Name", maxTag, errors.New("abcdef is more than 5"))),
+		},
+		{
+			name:   "create group selected, should validate create tags",
+			s:      &mock{},
+			groups: []string{"create"},
+			want:   newAggErr().addErr(newTagError("Name", requiredTag, errors.New("empty string"))),
+		},
+		{
+			name:   "update group selected, should validate update tags",
+			s:      &mock{},
+			groups: []string{"update"},
+			want:   newAggErr().addErr(newTagError("ID", requiredTag, errors.New("empty int"))),
+		},
+		{
+			name:   "admin group selected, tag with multiple groups should be validated",
+			s:      &mock{Name: "a"},
+			groups: []string{"admin"},
+			want: newAggErr().addErr(
+				newTagError("ID", requiredTag, errors.New("empty int")),
+				newTagError("Name", minTag, errors.New("a is less than 2"))),
+		},
+		{
+			name:   "argument has a group sign, should not be treated as a group",
+			s:      &mock{Mail: "a@b"},
+			groups: []string{"create"},
+			want:   newAggErr().addErr(newTagError("Name", requiredTag, errors.New("empty string"))),
+		},
+		{
+			name:   "create and update groups selected, conflicting tags should error",
+			s:      &mock{Name: "a"},
+			groups: []string{"create", "update"},
+			want: newAggErr().addErr(
+				newTagError("ID", requiredTag, errors.New("empty int")),
+				errors.New("a field can only have one of: optional, required, required_without")),
+		},
+		{
+			name:   "unknown group selected, should error",
+			s:      &mock{},
+			groups: []string{"delete"},
+			want:   newAggErr().addErr(errors.New("unknown group 'delete'")),
+		},
+		{
+			name:   "tag has an unknown group, should error",
+			s:      &mock2{},
+			groups: nil,
+			want:   newAggErr().addErr(errors.New("field: 'ID', tag 'required' has an unknown group 'unknown'")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := v.ValidateGroups(tt.s, tt.groups...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Vali.ValidateGroups() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("registering a group should recompile the plans", func(t *testing.T) {
+		v.RegisterGroups("unknown")
+		if err := v.ValidateGroups(&mock2{ID: 1}, "unknown"); err != nil {
+			t.Errorf("Vali.ValidateGroups() = %v, want nil", err)
+		}
+	})
+}
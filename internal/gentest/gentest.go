@@ -0,0 +1,80 @@
+// Package gentest holds the structs used to test
+// the validation methods generated by valigen.
+package gentest
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/tomasmik/vali"
+)
+
+//go:generate go run ../../cmd/valigen -type=User,Address -output=gentest_vali.go
+
+type Status string
+
+type User struct {
+	Name     string            `vali:"required|min=3|max=10"`
+	Nick     string            `vali:"required_without=*Name|max=5"`
+	Age      int               `vali:"optional|min=17|max=130"`
+	Level    uint8             `vali:"eq=3"`
+	Score    float64           `vali:"neq=1.5"`
+	Status   Status            `vali:"one_of=new,done"`
+	Role     string            `vali:"none_of=admin,root"`
+	Tags     []string          `vali:"required|min=0|max=3|dups"`
+	IDs      []int             `vali:"eq=2"`
+	Labels   map[string]string `vali:"required"`
+	Active   bool              `vali:"required"`
+	Timeout  time.Duration     `vali:"required"`
+	Email    string            `vali:"trim|lower|email"`
+	Login    string            `vali:"username"`
+	Code     string            `vali:"eq=a||eq=b"`
+	Address  *Address          `vali:"required"`
+	Home     Address           `vali:"required"`
+	Backup   *string           `vali:"required"`
+	Limit    float64           `vali:"min=5"`
+	Items    []int             `vali:">|min=1"`
+	Kind     string            `vali:"default=user|eq=user"`
+	Note     string            `vali:"warn:max=3"`
+	Untagged string
+	hidden   string `vali:"required"`
+}
+
+type Address struct {
+	City string `vali:"required|one_of=Vilnius,Kaunas"`
+}
+
+// Register registers the custom tags and aliases used by `User`.
+func Register(v *vali.Vali) error {
+	v.SetTagValidation("email", func(s interface{}, o []interface{}) error {
+		if !strings.Contains(vali.GetString(s), "@") {
+			return errors.New("not an email")
+		}
+		return nil
+	})
+	return v.RegisterAlias("username", "required|min=3")
+}
+
+// ValidUser returns a `User` which passes the validation.
+func ValidUser() User {
+	backup := "b"
+	return User{
+		Name:    "name",
+		Age:     18,
+		Level:   3,
+		Status:  "new",
+		Tags:    []string{"a"},
+		IDs:     []int{1, 2},
+		Labels:  map[string]string{},
+		Active:  true,
+		Timeout: time.Second,
+		Email:   " A@B ",
+		Login:   "login",
+		Code:    "a",
+		Address: &Address{City: "Vilnius"},
+		Backup:  &backup,
+		Limit:   6,
+		Items:   []int{2},
+	}
+}
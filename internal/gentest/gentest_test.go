@@ -0,0 +1,75 @@
+package gentest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tomasmik/vali"
+)
+
+func newVali(t *testing.T, v *vali.Vali) *vali.Vali {
+	t.Helper()
+	if err := Register(v); err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestGeneratedOptions(t *testing.T) {
+	v := newVali(t, vali.New())
+	u := User{}
+	err := v.ValidateWithOptions(&u, vali.ValidateOptions{MaxErrors: 2})
+
+	var agg *vali.AggErr
+	if !errors.As(err, &agg) || len(agg.Sl) != 2 {
+		t.Errorf("Vali.ValidateWithOptions() = %v, want 2 errors", err)
+	}
+}
+
+func TestGeneratedBubbleErr(t *testing.T) {
+	v := newVali(t, vali.New())
+	want := errors.New("bubbled")
+	v.SetTagValidation("email", func(s interface{}, o []interface{}) error {
+		return vali.BubbleErr(want)
+	})
+
+	u := ValidUser()
+	if got := v.Validate(&u); got != want {
+		t.Errorf("Vali.Validate() = %v, want %v", got, want)
+	}
+}
+
+func TestGeneratedTypeValidation(t *testing.T) {
+	v := newVali(t, vali.New())
+	want := errors.New("invalid address")
+	v.SetTypeValidation(&Address{}, func(s interface{}) error {
+		if _, ok := s.(*Address); !ok {
+			return errors.New("not an address")
+		}
+		return want
+	})
+
+	a := Address{City: "Vilnius"}
+	if got := v.Validate(&a); !errors.Is(got.(*vali.AggErr).Sl[0], want) || len(got.(*vali.AggErr).Sl) != 1 {
+		t.Errorf("Vali.Validate() = %v, want %v", got, want)
+	}
+	if got := a.ValidateValiWith(v.GenState(&a)); !errors.Is(got.(*vali.AggErr).Sl[0], want) {
+		t.Errorf("Address.ValidateValiWith() = %v, want %v", got, want)
+	}
+}
+
+func TestGeneratedCustomized(t *testing.T) {
+	v := newVali(t, vali.New())
+	want := errors.New("custom min")
+	v.SetTagValidation("min", func(s interface{}, o []interface{}) error {
+		return want
+	})
+
+	u := ValidUser()
+	err := v.Validate(&u)
+	var agg *vali.AggErr
+	var fe *vali.FieldError
+	if !errors.As(err, &agg) || !errors.As(agg.Sl[0], &fe) || fe.Field != "Name" || fe.Err != want {
+		t.Errorf("Vali.Validate() = %v, want the replaced min tag error", err)
+	}
+}
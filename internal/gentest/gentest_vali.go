@@ -0,0 +1,232 @@
+// Code generated by valigen; DO NOT EDIT.
+
+package gentest
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tomasmik/vali"
+)
+
+// ValidateVali validates the struct using the validator returned by `vali.DefaultVali`.
+func (x *User) ValidateVali() error {
+	return x.ValidateValiWith(vali.DefaultVali().GenState(x))
+}
+
+// ValidateValiWith validates the struct using the given validation state.
+func (x *User) ValidateValiWith(g *vali.GenState) error {
+	if g.Type() {
+		return g.Err()
+	}
+	if err := valigenUserName(x); err != nil && g.Fail(err) {
+		return g.Err()
+	}
+	if err := valigenUserNick(x); err != nil && g.Fail(err) {
+		return g.Err()
+	}
+	if err := valigenUserAge(x); err != nil && g.Fail(err) {
+		return g.Err()
+	}
+	if err := valigenUserLevel(x); err != nil && g.Fail(err) {
+		return g.Err()
+	}
+	if err := valigenUserScore(x); err != nil && g.Fail(err) {
+		return g.Err()
+	}
+	if err := valigenUserStatus(x); err != nil && g.Fail(err) {
+		return g.Err()
+	}
+	if err := valigenUserRole(x); err != nil && g.Fail(err) {
+		return g.Err()
+	}
+	if err := valigenUserTags(x); err != nil && g.Fail(err) {
+		return g.Err()
+	}
+	if err := valigenUserIDs(x); err != nil && g.Fail(err) {
+		return g.Err()
+	}
+	if err := valigenUserLabels(x); err != nil && g.Fail(err) {
+		return g.Err()
+	}
+	if err := valigenUserActive(x); err != nil && g.Fail(err) {
+		return g.Err()
+	}
+	if err := valigenUserTimeout(x); err != nil && g.Fail(err) {
+		return g.Err()
+	}
+	if g.Field("Email") {
+		return g.Err()
+	}
+	if g.Field("Login") {
+		return g.Err()
+	}
+	if g.Field("Code") {
+		return g.Err()
+	}
+	if g.Field("Address") {
+		return g.Err()
+	}
+	if g.Field("Home") {
+		return g.Err()
+	}
+	if g.Field("Backup") {
+		return g.Err()
+	}
+	if g.Field("Limit") {
+		return g.Err()
+	}
+	if g.Field("Items") {
+		return g.Err()
+	}
+	if g.Field("Kind") {
+		return g.Err()
+	}
+	if g.Field("Note") {
+		return g.Err()
+	}
+	return g.Err()
+}
+
+func valigenUserName(x *User) error {
+	if x.Name == "" {
+		return &vali.FieldError{Field: "Name", Tag: "required", Err: errors.New("empty string")}
+	}
+	if len(x.Name) < 3 {
+		return &vali.FieldError{Field: "Name", Tag: "min", Err: fmt.Errorf("%v is less than 3", x.Name)}
+	}
+	if len(x.Name) >= 10 {
+		return &vali.FieldError{Field: "Name", Tag: "max", Err: fmt.Errorf("%v is more than 10", x.Name)}
+	}
+	return nil
+}
+
+func valigenUserNick(x *User) error {
+	if x.Nick == "" {
+		if x.Name == "" {
+			return &vali.FieldError{Field: "Nick", Tag: "required_without", Err: errors.New("empty string")}
+		}
+		return nil
+	}
+	if len(x.Nick) >= 5 {
+		return &vali.FieldError{Field: "Nick", Tag: "max", Err: fmt.Errorf("%v is more than 5", x.Nick)}
+	}
+	return nil
+}
+
+func valigenUserAge(x *User) error {
+	if x.Age == 0 {
+		return nil
+	}
+	if int64(x.Age) <= 17 {
+		return &vali.FieldError{Field: "Age", Tag: "min", Err: fmt.Errorf("%v is less than 17", x.Age)}
+	}
+	if int64(x.Age) > 130 {
+		return &vali.FieldError{Field: "Age", Tag: "max", Err: fmt.Errorf("%v is more than 130", x.Age)}
+	}
+	return nil
+}
+
+func valigenUserLevel(x *User) error {
+	if uint64(x.Level) != 3 {
+		return &vali.FieldError{Field: "Level", Tag: "eq", Err: fmt.Errorf("%v is not equal to 3", x.Level)}
+	}
+	return nil
+}
+
+func valigenUserScore(x *User) error {
+	if float64(x.Score) == 1.5 {
+		return &vali.FieldError{Field: "Score", Tag: "neq", Err: fmt.Errorf("%v is equal to 1.5", x.Score)}
+	}
+	return nil
+}
+
+func valigenUserStatus(x *User) error {
+	if !(x.Status == "new" || x.Status == "done") {
+		return &vali.FieldError{Field: "Status", Tag: "one_of", Err: errors.New("must have at least one of [new done]")}
+	}
+	return nil
+}
+
+func valigenUserRole(x *User) error {
+	if x.Role == "admin" || x.Role == "root" {
+		return &vali.FieldError{Field: "Role", Tag: "none_of", Err: errors.New("must have none of [admin root]")}
+	}
+	return nil
+}
+
+func valigenUserTags(x *User) error {
+	if x.Tags == nil {
+		return &vali.FieldError{Field: "Tags", Tag: "required", Err: errors.New("[]string is nil")}
+	}
+	if len(x.Tags) <= 0 {
+		return &vali.FieldError{Field: "Tags", Tag: "min", Err: fmt.Errorf("%v is less than 0", x.Tags)}
+	}
+	if len(x.Tags) > 3 {
+		return &vali.FieldError{Field: "Tags", Tag: "max", Err: fmt.Errorf("%v is more than 3", x.Tags)}
+	}
+	{
+		seen := make(map[string]struct{}, len(x.Tags))
+		for _, e := range x.Tags {
+			seen[e] = struct{}{}
+		}
+		if len(seen) != len(x.Tags) {
+			return &vali.FieldError{Field: "Tags", Tag: "dups", Err: errors.New("no duplicates allowed")}
+		}
+	}
+	return nil
+}
+
+func valigenUserIDs(x *User) error {
+	if len(x.IDs) != 2 {
+		return &vali.FieldError{Field: "IDs", Tag: "eq", Err: fmt.Errorf("%v is not equal to 2", x.IDs)}
+	}
+	return nil
+}
+
+func valigenUserLabels(x *User) error {
+	if x.Labels == nil {
+		return &vali.FieldError{Field: "Labels", Tag: "required", Err: errors.New("map[string]string is nil")}
+	}
+	return nil
+}
+
+func valigenUserActive(x *User) error {
+	if !x.Active {
+		return &vali.FieldError{Field: "Active", Tag: "required", Err: errors.New("empty bool")}
+	}
+	return nil
+}
+
+func valigenUserTimeout(x *User) error {
+	if x.Timeout == 0 {
+		return &vali.FieldError{Field: "Timeout", Tag: "required", Err: errors.New("empty time.Duration")}
+	}
+	return nil
+}
+
+// ValidateVali validates the struct using the validator returned by `vali.DefaultVali`.
+func (x *Address) ValidateVali() error {
+	return x.ValidateValiWith(vali.DefaultVali().GenState(x))
+}
+
+// ValidateValiWith validates the struct using the given validation state.
+func (x *Address) ValidateValiWith(g *vali.GenState) error {
+	if g.Type() {
+		return g.Err()
+	}
+	if err := valigenAddressCity(x); err != nil && g.Fail(err) {
+		return g.Err()
+	}
+	return g.Err()
+}
+
+func valigenAddressCity(x *Address) error {
+	if x.City == "" {
+		return &vali.FieldError{Field: "City", Tag: "required", Err: errors.New("empty string")}
+	}
+	if !(x.City == "Vilnius" || x.City == "Kaunas") {
+		return &vali.FieldError{Field: "City", Tag: "one_of", Err: errors.New("must have at least one of [Vilnius Kaunas]")}
+	}
+	return nil
+}
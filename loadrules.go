@@ -0,0 +1,234 @@
+package vali
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// typeNames map stores the struct types by the names
+// they're referred to in the loaded rules.
+type typeNames map[string]reflect.Type
+
+// RegisterTypeName registers a name for the struct type of `typ`,
+// the name is used to refer to the type in the rules loaded with `LoadRules`.
+// Example:
+/*
+
+	err := v.RegisterTypeName("Order", &Order{})
+
+*/
+func (v *Vali) RegisterTypeName(name string, typ interface{}) error {
+	if name == "" {
+		return fmt.Errorf("type name can't be empty")
+	}
+
+	t, err := structType(typ)
+	if err != nil {
+		return err
+	}
+
+	v.typeNames[name] = t
+	return nil
+}
+
+// LoadRules reads a JSON document which maps the names of the types
+// registered with `RegisterTypeName` to their field paths and tag strings.
+// The rules behave the same way as the rules registered with `RegisterStructRules`.
+// The loaded rules are merged with the rules that were loaded before,
+// the rules of a field loaded again replace the old ones.
+//
+// Nested field paths are seperated by a `.`, the rules of a nested
+// field are set to the type of the nested struct, so they apply
+// everywhere that type is validated. Paths of different types which
+// point to the same nested field have to set the same rules,
+// otherwise the document is rejected.
+// Every tag has to be registered, the document is rejected as a whole
+// if any of the types, fields or tags are unknown or the syntax of the tags is invalid.
+//
+// It's safe to call while structs are being validated,
+// validations in progress keep using the rules they started with.
+// Example:
+/*
+
+	{
+		"Order": {
+			"Id": "required|max=64",
+			"Address.City": "one_of=Vilnius,Kaunas"
+		}
+	}
+
+*/
+func (v *Vali) LoadRules(r io.Reader) error {
+	return v.loadRules(r, true)
+}
+
+// Reload works the same as `LoadRules`, but the rules of the document
+// replace all of the rules that were loaded before.
+// The rules are swapped atomically, so it's safe to call
+// while structs are being validated.
+func (v *Vali) Reload(r io.Reader) error {
+	return v.loadRules(r, false)
+}
+
+func (v *Vali) loadRules(r io.Reader, merge bool) error {
+	var doc map[string]map[string]string
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return fmt.Errorf("failed to decode rules: %w", err)
+	}
+
+	parsed, err := v.parseRules(doc)
+	if err != nil {
+		return err
+	}
+
+	v.loadMu.Lock()
+	defer v.loadMu.Unlock()
+
+	loaded := rules{}
+	if merge {
+		for typ, fields := range v.loadPlans().loaded {
+			loaded[typ] = make(map[string][]tag, len(fields))
+			for name, tags := range fields {
+				loaded[typ][name] = tags
+			}
+		}
+	}
+	for typ, fields := range parsed {
+		if loaded[typ] == nil {
+			loaded[typ] = make(map[string][]tag, len(fields))
+		}
+		for name, tags := range fields {
+			loaded[typ][name] = tags
+		}
+	}
+
+	v.storePlans(loaded)
+	return nil
+}
+
+// parseRules resolves the types and the field paths of
+// the document and parses their tags.
+// The types and paths are walked in order, so the same
+// conflict is always reported for the same document.
+func (v *Vali) parseRules(doc map[string]map[string]string) (rules, error) {
+	parsed := rules{}
+	// sources holds the type and path which set the rules of a field,
+	// paths of different parents can point to the same nested field.
+	sources := map[reflect.Type]map[string]ruleSource{}
+	for _, name := range sortedKeys(doc) {
+		typ, ok := v.typeNames[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown type '%s'", name)
+		}
+
+		fields := doc[name]
+		for _, path := range sortedKeys(fields) {
+			tg := fields[path]
+			t, field, err := v.resolveFieldPath(typ, path)
+			if err != nil {
+				return nil, fmt.Errorf("type '%s': %w", name, err)
+			}
+
+			tags := parseTags(tg)
+			if err := v.checkRules(tags); err != nil {
+				return nil, fmt.Errorf("type '%s', field '%s': %w", name, path, err)
+			}
+
+			src := ruleSource{typ: name, path: path, tags: tg}
+			if prev, ok := sources[t][field]; ok && prev.tags != tg {
+				return nil, fmt.Errorf("type '%s', field '%s': conflicts with type '%s', field '%s', both set the rules of field '%s' of '%s'",
+					name, path, prev.typ, prev.path, field, t)
+			}
+
+			if parsed[t] == nil {
+				parsed[t] = map[string][]tag{}
+				sources[t] = map[string]ruleSource{}
+			}
+			parsed[t][field] = tags
+			sources[t][field] = src
+		}
+	}
+
+	return parsed, nil
+}
+
+// ruleSource is the entry of the document which set the rules of a field.
+type ruleSource struct {
+	typ  string
+	path string
+	tags string
+}
+
+// sortedKeys returns the keys of the map in order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// resolveFieldPath returns the struct type which holds
+// the last field of the path and the name of that field.
+func (v *Vali) resolveFieldPath(typ reflect.Type, path string) (reflect.Type, string, error) {
+	names := strings.Split(path, pathSep)
+	for i, name := range names {
+		f, err := v.ruleField(typ, name)
+		if err != nil {
+			return nil, "", err
+		}
+		if i == len(names)-1 {
+			return typ, name, nil
+		}
+
+		typ = f.Type
+		for typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+		if typ.Kind() != reflect.Struct {
+			return nil, "", fmt.Errorf("field '%s' is not a struct", name)
+		}
+	}
+
+	return nil, "", fmt.Errorf("field path is empty")
+}
+
+// checkRules validates the tags of a field the same way the struct tags are checked,
+// the tags have to be registered and their syntax has to be valid.
+func (v *Vali) checkRules(tags []tag) error {
+	if err := v.checkTagNames(tags); err != nil {
+		return err
+	}
+
+	expanded, err := v.expandAliases(tags)
+	if err != nil {
+		return err
+	}
+	return checkSyntax(expanded)
+}
+
+// checkTagNames validates that all of the tags are registered.
+func (v *Vali) checkTagNames(tags []tag) error {
+	for _, t := range tags {
+		for _, alt := range t.alts {
+			if err := v.checkTagNames(alt); err != nil {
+				return err
+			}
+		}
+		if len(t.alts) > 0 {
+			continue
+		}
+
+		if v.HasTag(t.name) {
+			continue
+		}
+		return fmt.Errorf("unknown tag '%s'", t.name)
+	}
+
+	return nil
+}
@@ -0,0 +1,210 @@
+package vali
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type loadAddress struct {
+	City string
+}
+
+type loadOrder struct {
+	ID      string `vali:"required"`
+	Status  string
+	Address *loadAddress `vali:"required"`
+}
+
+type loadCustomer struct {
+	Address loadAddress `vali:"required"`
+}
+
+func TestLoadRules(t *testing.T) {
+	v := New()
+	if err := v.RegisterTypeName("", &loadOrder{}); err == nil {
+		t.Error("expected an error for an empty type name")
+	}
+	if err := v.RegisterTypeName("Order", &loadOrder{}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		doc     string
+		wantErr bool
+	}{
+		{
+			name:    "not a json document, should error",
+			doc:     `rules`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown type, should error",
+			doc:     `{"Unknown": {"ID": "required"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown field, should error",
+			doc:     `{"Order": {"Unknown": "required"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "nested field of a field that's not a struct, should error",
+			doc:     `{"Order": {"ID.Unknown": "required"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown tag, should error",
+			doc:     `{"Order": {"ID": "required|unknown=1"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown tag in an OR group, should error",
+			doc:     `{"Order": {"ID": "eq=a||unknown"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "OR group with different severities, should error",
+			doc:     `{"Order": {"ID": "eq=a||warn:eq=b"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "negated OR group, should error",
+			doc:     `{"Order": {"ID": "not(eq=a||eq=b)"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "valid rules, should not error",
+			doc:     `{"Order": {"ID": "max=3", "Status": "optional|not(one_of=a,b)", "Address.City": ">|default||eq=x"}}`,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := v.LoadRules(strings.NewReader(tt.doc)); (err != nil) != tt.wantErr {
+				t.Errorf("Vali.LoadRules() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadRulesValidate(t *testing.T) {
+	v := New()
+	if err := v.RegisterTypeName("Order", loadOrder{}); err != nil {
+		t.Fatal(err)
+	}
+	s := &loadOrder{ID: "abcd", Status: "a", Address: &loadAddress{City: "Kaunas"}}
+
+	if err := v.LoadRules(strings.NewReader(`{"Order": {"ID": "max=3"}}`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.LoadRules(strings.NewReader(`{"Order": {"Status": "not(one_of=a,b)", "Address.City": "eq=Vilnius"}}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	want := newAggErr().addErr(
+		newTagError("ID", maxTag, errors.New("abcd is more than 3")),
+		newTagError("Status", "not(one_of)", errors.New("must not pass 'one_of' tag")),
+		newAggErr().addErr(withPath(newTagError("City", eqTag, errors.New("Kaunas is not equal to Vilnius")), "Address.City")),
+	)
+	if got := v.Validate(s); !reflect.DeepEqual(got, want) {
+		t.Errorf("Vali.Validate() = %v, want %v", got, want)
+	}
+
+	if err := v.Reload(strings.NewReader(`{"Order": {"Status": "eq=b"}}`)); err != nil {
+		t.Fatal(err)
+	}
+	want = newAggErr().addErr(newTagError("Status", eqTag, errors.New("a is not equal to b")))
+	if got := v.Validate(s); !reflect.DeepEqual(got, want) {
+		t.Errorf("Vali.Validate() after reload = %v, want %v", got, want)
+	}
+
+	if err := v.Reload(strings.NewReader(`{"Order": {"Status": "unknown"}}`)); err == nil {
+		t.Error("Vali.Reload() expected an error")
+	}
+	if got := v.Validate(s); !reflect.DeepEqual(got, want) {
+		t.Errorf("Vali.Validate() after a failed reload = %v, want %v", got, want)
+	}
+}
+
+func TestLoadRulesSharedType(t *testing.T) {
+	v := New()
+	for name, typ := range map[string]interface{}{"Order": loadOrder{}, "Customer": loadCustomer{}, "Address": loadAddress{}} {
+		if err := v.RegisterTypeName(name, typ); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		doc     string
+		wantErr string
+	}{
+		{
+			name:    "parents set different rules of the nested field, should error",
+			doc:     `{"Order": {"Address.City": "eq=Vilnius"}, "Customer": {"Address.City": "eq=Kaunas"}}`,
+			wantErr: "type 'Order', field 'Address.City': conflicts with type 'Customer', field 'Address.City', both set the rules of field 'City' of 'vali.loadAddress'",
+		},
+		{
+			name:    "parent and the nested type set different rules, should error",
+			doc:     `{"Address": {"City": "eq=Kaunas"}, "Order": {"Address.City": "eq=Vilnius"}}`,
+			wantErr: "type 'Order', field 'Address.City': conflicts with type 'Address', field 'City', both set the rules of field 'City' of 'vali.loadAddress'",
+		},
+		{
+			name: "parents set the same rules of the nested field, should not error",
+			doc:  `{"Order": {"Address.City": "eq=Vilnius"}, "Customer": {"Address.City": "eq=Vilnius"}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Reload(strings.NewReader(tt.doc))
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("Vali.Reload() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Fatalf("Vali.Reload() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// The rules of the nested type apply to both of the parents
+	want := newAggErr().addErr(newAggErr().addErr(withPath(newTagError("City", eqTag, errors.New("Kaunas is not equal to Vilnius")), "Address.City")))
+	if got := v.Validate(&loadCustomer{Address: loadAddress{City: "Kaunas"}}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Vali.Validate() = %v, want %v", got, want)
+	}
+}
+
+func TestReloadConcurrent(t *testing.T) {
+	v := New()
+	if err := v.RegisterTypeName("Order", &loadOrder{}); err != nil {
+		t.Fatal(err)
+	}
+
+	docs := []string{
+		`{"Order": {"Status": "eq=a"}}`,
+		`{"Order": {"Status": "one_of=a,b"}}`,
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				s := &loadOrder{ID: "a", Status: "a", Address: &loadAddress{}}
+				if err := v.Validate(s); err != nil {
+					t.Errorf("Vali.Validate() error = %v", err)
+					return
+				}
+			}
+		}()
+	}
+	for j := 0; j < 100; j++ {
+		if err := v.Reload(strings.NewReader(docs[j%len(docs)])); err != nil {
+			t.Fatal(err)
+		}
+	}
+	wg.Wait()
+}
@@ -0,0 +1,148 @@
+package vali
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Components is the `components` object of an OpenAPI 3.1 document.
+type Components struct {
+	Schemas map[string]Schema `json:"schemas"`
+}
+
+// OpenAPIComponents returns the OpenAPI 3.1 component schemas of the given struct types
+// and the named structs nested in them, built the same way as `JSONSchema`.
+// Nested structs are referenced with `$ref` and the `required` arrays
+// are built from the `required` and `required_without` tags.
+// Example:
+/*
+
+	c, err := vali.OpenAPIComponents(v, reflect.TypeOf(User{}), reflect.TypeOf(Order{}))
+	...
+	b, err := c.YAML()
+
+*/
+func OpenAPIComponents(v *Vali, typs ...reflect.Type) (*Components, error) {
+	b := newSchemaBuilder(v, "#/components/schemas/")
+	for _, typ := range typs {
+		typ = derefType(typ)
+		if typ == nil || typ.Kind() != reflect.Struct || typ.Name() == "" {
+			return nil, fmt.Errorf("function only accepts named struct types; got %v", typ)
+		}
+
+		if _, err := b.ref(typ); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Components{Schemas: b.defs}, nil
+}
+
+// JSON returns the components as a JSON document
+// with a `components` key, so it can be merged in to an OpenAPI document.
+func (c *Components) JSON() ([]byte, error) {
+	return json.MarshalIndent(map[string]*Components{"components": c}, "", "  ")
+}
+
+// YAML returns the components as a YAML document
+// with a `components` key, so it can be merged in to an OpenAPI document.
+func (c *Components) YAML() ([]byte, error) {
+	b, err := json.Marshal(map[string]*Components{"components": c})
+	if err != nil {
+		return nil, err
+	}
+
+	// The document is decoded back to keep the
+	// values the same as they're encoded in JSON.
+	var doc interface{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	buf := &bytes.Buffer{}
+	writeYAML(buf, doc, "")
+	return buf.Bytes(), nil
+}
+
+// writeYAML writes the mapping or the sequence as a YAML block,
+// every line of the block is indented by `indent`.
+func writeYAML(buf *bytes.Buffer, val interface{}, indent string) {
+	switch val := val.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			buf.WriteString(indent + yamlScalar(k) + ":")
+			writeYAMLValue(buf, val[k], indent)
+		}
+	case []interface{}:
+		for _, item := range val {
+			buf.WriteString(indent + "-")
+			writeYAMLValue(buf, item, indent)
+		}
+	}
+}
+
+// writeYAMLValue writes the value of a mapping key or a sequence item,
+// nested blocks are indented deeper than their parent.
+func writeYAMLValue(buf *bytes.Buffer, val interface{}, indent string) {
+	switch v := val.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 {
+			buf.WriteString(" {}\n")
+			return
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			buf.WriteString(" []\n")
+			return
+		}
+	default:
+		buf.WriteString(" " + yamlScalar(v) + "\n")
+		return
+	}
+
+	buf.WriteString("\n")
+	writeYAML(buf, val, indent+"  ")
+}
+
+var (
+	// yamlPlain matches the strings which don't have to be quoted.
+	yamlPlain = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$./\-]*$`)
+	// yamlReserved holds the plain strings which YAML parsers read as other types.
+	yamlReserved = map[string]struct{}{
+		"true": {}, "false": {}, "null": {}, "yes": {}, "no": {}, "on": {}, "off": {}, "y": {}, "n": {},
+	}
+)
+
+// yamlScalar returns a scalar value as YAML,
+// strings are quoted if they could be read as anything else.
+func yamlScalar(val interface{}) string {
+	switch v := val.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(v)
+	case json.Number:
+		return v.String()
+	case string:
+		if _, ok := yamlReserved[strings.ToLower(v)]; ok || !yamlPlain.MatchString(v) {
+			return strconv.Quote(v)
+		}
+		return v
+	}
+
+	return strconv.Quote(fmt.Sprint(val))
+}
@@ -0,0 +1,146 @@
+package vali
+
+import (
+	"reflect"
+	"testing"
+)
+
+type openAPIOrder struct {
+	ID      string         `json:"id" vali:"required|max=65"`
+	Email   string         `json:"email" vali:"required_without=*Phone"`
+	Phone   string         `json:"phone"`
+	Note    string         `json:"note" vali:"one_of=yes,a b"`
+	Address *schemaAddress `json:"address" vali:"required"`
+	Tags    []string       `json:"tags"`
+	Meta    struct{}       `json:"meta"`
+}
+
+func TestOpenAPIComponents(t *testing.T) {
+	v := New()
+	tests := []struct {
+		name    string
+		typs    []reflect.Type
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "nested structs referenced, should not error",
+			typs: []reflect.Type{reflect.TypeOf(&openAPIOrder{}), reflect.TypeOf(schemaNode{})},
+			want: `{
+				"schemas": {
+					"openAPIOrder": {
+						"type": "object",
+						"properties": {
+							"id": {"type": "string", "maxLength": 64},
+							"email": {"type": "string"},
+							"phone": {"type": "string"},
+							"note": {"type": "string", "enum": ["yes", "a b"]},
+							"address": {"$ref": "#/components/schemas/schemaAddress"},
+							"tags": {"type": "array", "items": {"type": "string"}},
+							"meta": {"type": "object", "properties": {}}
+						},
+						"required": ["id", "address"],
+						"anyOf": [{"required": ["email"]}, {"required": ["phone"]}]
+					},
+					"schemaAddress": {
+						"type": "object",
+						"properties": {
+							"city": {"type": "string", "enum": ["Vilnius", "Kaunas"]}
+						},
+						"required": ["city"]
+					},
+					"schemaNode": {
+						"type": "object",
+						"properties": {
+							"name": {"type": "string"},
+							"children": {"type": "array", "items": {"$ref": "#/components/schemas/schemaNode"}}
+						},
+						"required": ["name"]
+					}
+				}
+			}`,
+		},
+		{
+			name:    "anonymous struct, should error",
+			typs:    []reflect.Type{reflect.TypeOf(struct{}{})},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := OpenAPIComponents(v, tt.typs...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("OpenAPIComponents() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if !jsonEqual(t, got, tt.want) {
+				t.Errorf("OpenAPIComponents() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComponentsYAML(t *testing.T) {
+	c, err := OpenAPIComponents(New(), reflect.TypeOf(openAPIOrder{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `components:
+  schemas:
+    openAPIOrder:
+      anyOf:
+        -
+          required:
+            - email
+        -
+          required:
+            - phone
+      properties:
+        address:
+          $ref: "#/components/schemas/schemaAddress"
+        email:
+          type: string
+        id:
+          maxLength: 64
+          type: string
+        meta:
+          properties: {}
+          type: object
+        note:
+          enum:
+            - "yes"
+            - "a b"
+          type: string
+        phone:
+          type: string
+        tags:
+          items:
+            type: string
+          type: array
+      required:
+        - id
+        - address
+      type: object
+    schemaAddress:
+      properties:
+        city:
+          enum:
+            - Vilnius
+            - Kaunas
+          type: string
+      required:
+        - city
+      type: object
+`
+	got, err := c.YAML()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != want {
+		t.Errorf("Components.YAML() = %s, want %s", got, want)
+	}
+}
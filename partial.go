@@ -0,0 +1,211 @@
+package vali
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// filter decides which struct fields get validated
+// when only a part of a struct has to be validated.
+type filter struct {
+	// paths holds the field paths, nested fields are seperated with `pathSep`.
+	paths map[string]struct{}
+	// except inverts the filter, making it skip the given paths.
+	except bool
+	// exact only matches the given paths, selecting a struct
+	// field doesn't select its nested fields.
+	exact bool
+	// fold matches the paths case-insensitively when
+	// there is no exact match, the same way `encoding/json` matches the keys.
+	fold bool
+	// name returns the name of the field used in the paths,
+	// the fields it reports false for are never matched.
+	name func(f reflect.StructField) (string, bool)
+}
+
+func newFilter(paths []string, except bool, name func(f reflect.StructField) (string, bool)) *filter {
+	m := make(map[string]struct{}, len(paths))
+	for _, p := range paths {
+		m[p] = struct{}{}
+	}
+
+	return &filter{
+		paths:  m,
+		except: except,
+		name:   name,
+	}
+}
+
+// fieldName returns the name of the struct field
+// that is used when building its path, it reports false if the field can't be matched.
+func (f *filter) fieldName(sf reflect.StructField) (string, bool) {
+	if f == nil || f.name == nil {
+		return sf.Name, true
+	}
+	return f.name(sf)
+}
+
+// match reports whether the tags of the field at the given path
+// should be validated and whether its nested struct should be walked.
+func (f *filter) match(path string) (validate, descend bool) {
+	if f == nil {
+		return true, true
+	}
+
+	covered := f.covers(path)
+	if f.except {
+		return !covered, !covered
+	}
+	if covered {
+		return true, true
+	}
+
+	for p := range f.paths {
+		if f.hasPrefix(p, path+pathSep) {
+			return false, true
+		}
+	}
+	return false, false
+}
+
+// covers reports whether the path or any of its parents are in the filter,
+// only the path itself is checked if the filter is exact.
+func (f *filter) covers(path string) bool {
+	if f.exact {
+		return f.has(path)
+	}
+	for {
+		if f.has(path) {
+			return true
+		}
+
+		i := strings.LastIndex(path, pathSep)
+		if i < 0 {
+			return false
+		}
+		path = path[:i]
+	}
+}
+
+// has reports whether the path is in the filter.
+func (f *filter) has(path string) bool {
+	if _, ok := f.paths[path]; ok || !f.fold {
+		return ok
+	}
+	for p := range f.paths {
+		if strings.EqualFold(p, path) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *filter) hasPrefix(s, prefix string) bool {
+	if f.fold {
+		return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+	}
+	return strings.HasPrefix(s, prefix)
+}
+
+// ValidatePartial works the same as `Validate` but only validates
+// the fields found in the given paths. Nested fields are pointed to
+// by joining the field names with a `.`, selecting a struct field
+// validates all of its nested fields as well.
+// Type validation funcs are only called for structs that are fully selected.
+// Example:
+/*
+
+	err := v.ValidatePartial(user, "Name", "Address.City")
+
+*/
+func (v *Vali) ValidatePartial(s interface{}, paths ...string) error {
+	st := newState(ValidateOptions{})
+	st.filter = newFilter(paths, false, nil)
+	return v.validate(s, st)
+}
+
+// ValidateExcept works the same as `Validate` but skips the
+// fields found in the given paths. Paths are written the same way
+// as for `ValidatePartial`.
+// Example:
+/*
+
+	err := v.ValidateExcept(user, "Password", "Address.Zip")
+
+*/
+func (v *Vali) ValidateExcept(s interface{}, paths ...string) error {
+	st := newState(ValidateOptions{})
+	st.filter = newFilter(paths, true, nil)
+	return v.validate(s, st)
+}
+
+// ValidatePresent works the same as `ValidatePartial` but the paths
+// are made of the `json` tag names of the fields, making it easy to
+// only validate the fields that were present in a JSON document.
+// Fields without a `json` tag use their field name and fields left out of JSON
+// with `json:"-"` are never validated. The same as `encoding/json` the paths
+// are matched case-insensitively if there is no exact match. Unlike `ValidatePartial` a path of a struct
+// field only validates the field itself and its type validation func, its nested
+// fields are only validated if their paths are given as well.
+// `JSONPaths` can be used to get the paths from a JSON document.
+// Example:
+/*
+
+	paths, err := vali.JSONPaths(body)
+	if err != nil {
+		return err
+	}
+	err = v.ValidatePresent(user, paths)
+
+*/
+func (v *Vali) ValidatePresent(s interface{}, presentPaths []string) error {
+	st := newState(ValidateOptions{})
+	st.filter = newFilter(presentPaths, false, jsonName)
+	st.filter.exact = true
+	st.filter.fold = true
+	return v.validate(s, st)
+}
+
+// JSONPaths returns the paths of all the keys present in the
+// given JSON object that can be used with `ValidatePresent`.
+// Keys holding objects are returned along with their nested keys -
+// `{"address":{"city":"a"}}` returns "address" and "address.city".
+func JSONPaths(data []byte) ([]string, error) {
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+
+	return jsonPaths("", m, []string{}), nil
+}
+
+func jsonPaths(prefix string, m map[string]interface{}, paths []string) []string {
+	for k, val := range m {
+		path := k
+		if prefix != "" {
+			path = prefix + pathSep + k
+		}
+
+		paths = append(paths, path)
+		if nested, ok := val.(map[string]interface{}); ok {
+			paths = jsonPaths(path, nested, paths)
+		}
+	}
+	return paths
+}
+
+// jsonName returns the name used by the `encoding/json` package for the field,
+// it reports false for the fields left out of JSON with `json:"-"`.
+func jsonName(f reflect.StructField) (string, bool) {
+	tg := f.Tag.Get("json")
+	if tg == "-" {
+		return "", false
+	}
+
+	name := strings.Split(tg, valueSep)[0]
+	if name == "" {
+		return f.Name, true
+	}
+	return name, true
+}
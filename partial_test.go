@@ -0,0 +1,227 @@
+package vali
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+type partialAddress struct {
+	City string `json:"city" vali:"required"`
+	Zip  string `json:"zip" vali:"required"`
+}
+
+type partialUser struct {
+	Name    string          `json:"name" vali:"required"`
+	Age     int             `json:"age" vali:"min=18"`
+	Address *partialAddress `json:"address" vali:"required"`
+}
+
+func TestValidatePartial(t *testing.T) {
+	s := &partialUser{
+		Age:     1,
+		Address: &partialAddress{},
+	}
+	tests := []struct {
+		name  string
+		paths []string
+		want  error
+	}{
+		{
+			name:  "unknown field selected, should not error",
+			paths: []string{"Unknown"},
+			want:  nil,
+		},
+		{
+			name:  "top level field selected, should only validate it",
+			paths: []string{"Name"},
+			want:  newAggErr().addErr(newTagError("Name", requiredTag, errors.New("empty string"))),
+		},
+		{
+			name:  "nested field selected, should only validate it",
+			paths: []string{"Address.City"},
+			want:  newAggErr().addErr(newAggErr().addErr(withPath(newTagError("City", requiredTag, errors.New("empty string")), "Address.City"))),
+		},
+		{
+			name:  "nested struct selected, should validate all of its fields",
+			paths: []string{"Address"},
+			want: newAggErr().addErr(newAggErr().addErr(
+				withPath(newTagError("City", requiredTag, errors.New("empty string")), "Address.City"),
+				withPath(newTagError("Zip", requiredTag, errors.New("empty string")), "Address.Zip"))),
+		},
+	}
+
+	v := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := v.ValidatePartial(s, tt.paths...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Vali.ValidatePartial() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidatePartialTypeValidation(t *testing.T) {
+	v := New()
+	v.SetTypeValidation(&partialAddress{}, func(s interface{}) error {
+		return errors.New("address is not valid")
+	})
+	s := &partialUser{
+		Name:    "a",
+		Age:     18,
+		Address: &partialAddress{City: "a", Zip: "b"},
+	}
+
+	if err := v.ValidatePartial(s, "Address.City"); err != nil {
+		t.Errorf("Vali.ValidatePartial() = %v, want nil", err)
+	}
+	if err := v.ValidatePartial(s, "Address"); err == nil {
+		t.Error("Vali.ValidatePartial() = nil, want type validation error")
+	}
+}
+
+func TestValidateExcept(t *testing.T) {
+	s := &partialUser{
+		Age:     1,
+		Address: &partialAddress{},
+	}
+	tests := []struct {
+		name  string
+		paths []string
+		want  error
+	}{
+		{
+			name:  "every invalid field excluded, should not error",
+			paths: []string{"Name", "Age", "Address"},
+			want:  nil,
+		},
+		{
+			name:  "nested field excluded, should validate the rest",
+			paths: []string{"Name", "Age", "Address.City"},
+			want:  newAggErr().addErr(newAggErr().addErr(withPath(newTagError("Zip", requiredTag, errors.New("empty string")), "Address.Zip"))),
+		},
+	}
+
+	v := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := v.ValidateExcept(s, tt.paths...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Vali.ValidateExcept() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidatePresent(t *testing.T) {
+	s := &partialUser{
+		Name:    "a",
+		Age:     1,
+		Address: &partialAddress{},
+	}
+	paths, err := JSONPaths([]byte(`{"name":"a","address":{"zip":""}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := newAggErr().addErr(newAggErr().addErr(withPath(newTagError("Zip", requiredTag, errors.New("empty string")), "Address.Zip")))
+	if got := New().ValidatePresent(s, paths); !reflect.DeepEqual(got, want) {
+		t.Errorf("Vali.ValidatePresent() = %v, want %v", got, want)
+	}
+
+	t.Run("empty object present, should only validate the field and its type", func(t *testing.T) {
+		called := 0
+		v := New()
+		v.SetTypeValidation(partialAddress{}, func(s interface{}) error {
+			called++
+			return nil
+		})
+
+		paths, err := JSONPaths([]byte(`{"address":{}}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := v.ValidatePresent(&partialUser{Address: &partialAddress{}}, paths); got != nil {
+			t.Errorf("Vali.ValidatePresent() = %v, want nil", got)
+		}
+		if called != 1 {
+			t.Errorf("type validation called %d times, want 1", called)
+		}
+	})
+
+	t.Run("null object present, should validate the tags of the field", func(t *testing.T) {
+		paths, err := JSONPaths([]byte(`{"address":null}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := newAggErr().addErr(newTagError("Address", requiredTag, errors.New("value is nil")))
+		if got := New().ValidatePresent(&partialUser{}, paths); !reflect.DeepEqual(got, want) {
+			t.Errorf("Vali.ValidatePresent() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("keys in a different case, should match the fields like encoding/json", func(t *testing.T) {
+		paths, err := JSONPaths([]byte(`{"NAME":"","Address":{"Zip":""}}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := newAggErr().addErr(
+			newTagError("Name", requiredTag, errors.New("empty string")),
+			newAggErr().addErr(withPath(newTagError("Zip", requiredTag, errors.New("empty string")), "Address.Zip")),
+		)
+		if got := New().ValidatePresent(&partialUser{Address: &partialAddress{}}, paths); !reflect.DeepEqual(got, want) {
+			t.Errorf("Vali.ValidatePresent() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("field left out of JSON, should never be validated", func(t *testing.T) {
+		s := &struct {
+			Secret string `json:"-" vali:"required"`
+			Dash   string `json:"-," vali:"required"`
+		}{}
+		want := newAggErr().addErr(withPath(newTagError("Dash", requiredTag, errors.New("empty string")), "Dash"))
+		if got := New().ValidatePresent(s, []string{"Secret", "-", ""}); !reflect.DeepEqual(got, want) {
+			t.Errorf("Vali.ValidatePresent() = %v, want %v", got, want)
+		}
+	})
+}
+
+func TestJSONPaths(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "flat object, should return all keys",
+			data: `{"a":1,"b":"c"}`,
+			want: []string{"a", "b"},
+		},
+		{
+			name: "nested object, should return the keys holding objects and their nested keys",
+			data: `{"a":{"b":{"c":1}},"d":[1,2],"e":{}}`,
+			want: []string{"a", "a.b", "a.b.c", "d", "e"},
+		},
+		{
+			name:    "not an object, should error",
+			data:    `[1,2]`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := JSONPaths([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("JSONPaths() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("JSONPaths() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
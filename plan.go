@@ -0,0 +1,229 @@
+package vali
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// plan is the compiled validation plan of a struct type.
+// It holds the parsed tags of every field, so the tags
+// don't have to be parsed every time a struct is validated.
+type plan struct {
+	fields []fieldPlan
+	// visible holds the exported fields of the struct with the fields of
+	// its flattened embedded structs, the embedded structs are left out.
+	visible []reflect.StructField
+	// embeds holds the indexes of the flattened embedded structs.
+	embeds [][]int
+	// refs holds the indexes of the fields which can be pointed to with `*Field`.
+	refs map[string][]int
+}
+
+// fieldPlan is the validation plan of a single struct field.
+type fieldPlan struct {
+	// index is the index sequence of the field,
+	// it's longer than one for the fields of flattened embedded structs.
+	index []int
+	field reflect.StructField
+	tags  []tag
+	// err is set if the tags of the field are not valid,
+	// the field is not validated if it's set.
+	err error
+	// grouped is set if any of the tags belong to a validation group.
+	grouped bool
+	// flat is set for the flattened embedded structs,
+	// they're not descended in to as their fields are validated with the struct.
+	flat bool
+}
+
+// plans caches the compiled plans by their struct type.
+// A validation uses the same plans from start to end, so
+// replacing them doesn't affect validations that are in progress.
+type plans struct {
+	m sync.Map
+	// loaded holds the rules loaded with `LoadRules`,
+	// they're compiled in to the plans with the other rules.
+	loaded rules
+}
+
+// loadPlans returns the current plans.
+func (v *Vali) loadPlans() *plans {
+	return v.plans.Load().(*plans)
+}
+
+// plan returns the validation plan for the given struct type,
+// compiling it if it wasn't compiled before.
+func (v *Vali) plan(ps *plans, typ reflect.Type) *plan {
+	if p, ok := ps.m.Load(typ); ok {
+		return p.(*plan)
+	}
+
+	p, _ := ps.m.LoadOrStore(typ, v.compilePlan(typ, ps.loaded))
+	return p.(*plan)
+}
+
+// resetPlans drops all of the compiled plans, it has to be
+// called every time the configuration used to compile them changes.
+// The loaded rules are locked, so a reload running at the same time is not undone.
+func (v *Vali) resetPlans() {
+	v.loadMu.Lock()
+	defer v.loadMu.Unlock()
+	v.storePlans(v.loadPlans().loaded)
+}
+
+// storePlans replaces the plans with empty ones
+// which use the given loaded rules.
+func (v *Vali) storePlans(loaded rules) {
+	v.plans.Store(&plans{loaded: loaded})
+}
+
+func (v *Vali) compilePlan(typ reflect.Type, loaded rules) *plan {
+	p := &plan{
+		fields: make([]fieldPlan, 0, typ.NumField()),
+		refs:   map[string][]int{},
+	}
+
+	for _, f := range v.visibleFields(typ) {
+		flat := v.flattens(f)
+		if flat {
+			p.embeds = append(p.embeds, f.Index)
+		} else {
+			p.visible = append(p.visible, f)
+		}
+		p.refs[f.Name] = f.Index
+
+		tags := append(parseTags(f.Tag.Get(v.tgName)), v.rules[typ][f.Name]...)
+		tags = append(tags, loaded[typ][f.Name]...)
+		// Promoted fields keep the rules of the embedded struct
+		if len(f.Index) > 1 {
+			owner := typ.FieldByIndex(f.Index[:len(f.Index)-1]).Type
+			for owner.Kind() == reflect.Ptr {
+				owner = owner.Elem()
+			}
+			tags = append(tags, v.rules[owner][f.Name]...)
+			tags = append(tags, loaded[owner][f.Name]...)
+		}
+		tags, err := v.expandAliases(tags)
+		if err == nil {
+			err = checkSyntax(tags)
+		}
+		if len(tags) == 0 && err == nil {
+			continue
+		}
+
+		fp := fieldPlan{
+			index: f.Index,
+			field: f,
+			tags:  tags,
+			err:   err,
+			flat:  flat,
+		}
+		if fp.err != nil {
+			p.fields = append(p.fields, fp)
+			continue
+		}
+		fp.grouped, fp.err = v.checkGroups(f.Name, tags)
+		if fp.err == nil && !fp.grouped {
+			fp.err = validateTags(tagSliceToMap(tags))
+		}
+		p.fields = append(p.fields, fp)
+	}
+
+	return p
+}
+
+// visibleFields returns the exported fields of the struct, the fields of embedded
+// structs are promoted the same way `encoding/json` does, so outer fields hide
+// the embedded ones of the same name. Embedded structs with the `no_flatten` tag
+// are kept as a single field named after their type.
+func (v *Vali) visibleFields(typ reflect.Type) []reflect.StructField {
+	fields := make([]reflect.StructField, 0, typ.NumField())
+	kept := [][]int{}
+	for _, f := range reflect.VisibleFields(typ) {
+		if hasIndexPrefix(f.Index, kept) {
+			continue
+		}
+		if isEmbeddedStruct(f) && !v.flattens(f) {
+			kept = append(kept, f.Index)
+		}
+		// Ignore unexported fields
+		if f.PkgPath != "" {
+			continue
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+// visibleField returns the exported field of the struct by its name,
+// fields of flattened embedded structs are included.
+func (v *Vali) visibleField(typ reflect.Type, name string) (reflect.StructField, bool) {
+	for _, f := range v.visibleFields(typ) {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// flattens reports whether the fields of the embedded struct are promoted.
+func (v *Vali) flattens(f reflect.StructField) bool {
+	if !isEmbeddedStruct(f) {
+		return false
+	}
+	for _, t := range parseTags(f.Tag.Get(v.tgName)) {
+		if t.name == noFlattenTag {
+			return false
+		}
+	}
+	return true
+}
+
+func isEmbeddedStruct(f reflect.StructField) bool {
+	typ := f.Type
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return f.Anonymous && typ.Kind() == reflect.Struct
+}
+
+// hasIndexPrefix reports whether the index is of a field nested in any of the given fields.
+func hasIndexPrefix(index []int, prefixes [][]int) bool {
+	for _, p := range prefixes {
+		if len(index) > len(p) && reflect.DeepEqual(index[:len(p)], p) {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldByIndex returns the field of the struct at the index sequence, it reports
+// false if any of the embedded structs on the way is a nil pointer.
+func fieldByIndex(val reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && val.Kind() == reflect.Ptr {
+			if val.IsNil() {
+				return reflect.Value{}, false
+			}
+			val = val.Elem()
+		}
+		val = val.Field(x)
+	}
+	return val, true
+}
+
+// checkGroups validates that all of the tag groups are registered
+// and reports whether any of the tags belong to a group.
+func (v *Vali) checkGroups(field string, tags []tag) (bool, error) {
+	grouped := false
+	for _, t := range tags {
+		for _, g := range t.groups {
+			grouped = true
+			if _, ok := v.groups[g]; !ok {
+				return grouped, fmt.Errorf("field: '%s', tag '%s' has an unknown group '%s'", field, t.name, g)
+			}
+		}
+	}
+	return grouped, nil
+}
@@ -0,0 +1,97 @@
+package vali
+
+import (
+	"errors"
+)
+
+// Severity defines how a failed tag affects the validation.
+type Severity int
+
+const (
+	// SeverityError makes the field invalid if the tag fails,
+	// it's the default severity of every tag.
+	SeverityError Severity = iota
+	// SeverityWarning only reports the failed tag as a warning,
+	// the field is still considered valid and the remaining tags are validated.
+	SeverityWarning
+)
+
+// Report holds the outcome of the `ValidateReport` method.
+type Report struct {
+	// Errors holds the errors that make the struct invalid,
+	// these are the same errors that `Validate` returns.
+	Errors *AggErr
+	// Warnings holds the errors of tags that have the `SeverityWarning` severity.
+	Warnings *AggErr
+}
+
+// Err returns the errors of the report as a single error
+// or nil if the struct is valid.
+func (r *Report) Err() error {
+	return r.Errors.toError()
+}
+
+// HasWarnings reports whether any warnings were found.
+func (r *Report) HasWarnings() bool {
+	return len(r.Warnings.Sl) > 0
+}
+
+// ValidateReport works the same as `Validate`, but
+// returns both the errors and the warnings found in the struct.
+// Example:
+/*
+
+	r := vali.New().ValidateReport(str)
+	if err := r.Err(); err != nil {
+		return err
+	}
+	if r.HasWarnings() {
+		log.Println(r.Warnings)
+	}
+
+*/
+func (v *Vali) ValidateReport(s interface{}) *Report {
+	st := newState(ValidateOptions{})
+	r := &Report{
+		Errors:   newAggErr(),
+		Warnings: newAggErr(),
+	}
+
+	if err := v.validate(s, st); err != nil {
+		var agg *AggErr
+		if !st.bubbled && errors.As(err, &agg) {
+			r.Errors = agg
+		} else {
+			r.Errors.addErr(err)
+		}
+	}
+	r.Warnings.addErr(st.warnings...)
+
+	return r
+}
+
+// SetTagSeverity sets the severity of the given tag
+// for every field it's used on. A `warn:` prefix on
+// a tag in a struct field overrides this severity.
+// Example:
+/*
+
+	v.SetTagSeverity("deprecated", vali.SeverityWarning)
+
+*/
+func (v *Vali) SetTagSeverity(tag string, sev Severity) {
+	if tag == "" {
+		return
+	}
+
+	v.customize(tag)
+	v.severities[tag] = sev
+}
+
+// severity returns the severity of the given tag.
+func (v *Vali) severity(t tag) Severity {
+	if t.severity != SeverityError {
+		return t.severity
+	}
+	return v.severities[t.name]
+}
@@ -0,0 +1,82 @@
+package vali
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestValidateReport(t *testing.T) {
+	type Inner struct {
+		First string `vali:"warn:one_of=a,b"`
+	}
+	type mock struct {
+		First  string `vali:"required|warn:max=3"`
+		Second int    `vali:"deprecated|min=2"`
+		In     *Inner `vali:"required"`
+	}
+
+	v := New()
+	v.SetTagSeverity("", SeverityWarning)
+	if _, ok := v.severities[""]; ok {
+		t.Error("expected empty tag to be ignored")
+	}
+	v.SetTagValidation("deprecated", func(s interface{}, o []interface{}) error {
+		if i, _ := GetInt(s); i == 4 {
+			return errors.New("4 is deprecated")
+		}
+		return nil
+	})
+	v.SetTagSeverity("deprecated", SeverityWarning)
+
+	tests := []struct {
+		name         string
+		s            interface{}
+		wantErr      error
+		wantWarnings []error
+	}{
+		{
+			name:         "valid struct, should have no errors and no warnings",
+			s:            &mock{First: "ab", Second: 3, In: &Inner{First: "a"}},
+			wantErr:      nil,
+			wantWarnings: []error{},
+		},
+		{
+			name:    "warning tags fail, should only have warnings",
+			s:       &mock{First: "abcd", Second: 4, In: &Inner{First: "c"}},
+			wantErr: nil,
+			wantWarnings: []error{
+				newTagError("First", maxTag, errors.New("abcd is more than 3")),
+				newTagError("Second", "deprecated", errors.New("4 is deprecated")),
+				withPath(newTagError("First", oneofTag, errors.New("must have at least one of [a b]")), "In.First"),
+			},
+		},
+		{
+			name: "warning and error tags fail, should have both",
+			s:    &mock{First: "abcd", Second: 1, In: &Inner{First: "a"}},
+			wantErr: newAggErr().addErr(
+				newTagError("Second", minTag, errors.New("1 is less than 2"))),
+			wantWarnings: []error{
+				newTagError("First", maxTag, errors.New("abcd is more than 3")),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := v.ValidateReport(tt.s)
+			if got := r.Err(); !reflect.DeepEqual(got, tt.wantErr) {
+				t.Errorf("Report.Err() = %v, want %v", got, tt.wantErr)
+			}
+			if !reflect.DeepEqual(r.Warnings.Sl, tt.wantWarnings) {
+				t.Errorf("Report.Warnings = %v, want %v", r.Warnings.Sl, tt.wantWarnings)
+			}
+			if r.HasWarnings() != (len(tt.wantWarnings) > 0) {
+				t.Errorf("Report.HasWarnings() = %v, want %v", r.HasWarnings(), len(tt.wantWarnings) > 0)
+			}
+			if err := v.Validate(tt.s); !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("Vali.Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,7 +1,54 @@
 package vali
 
+import (
+	"errors"
+)
+
+// Result is the return value of a `ResultFunc`.
+// It allows a tag func to tell the validator how the validation
+// of a field should continue, instead of relying on special errors.
+// A zero value Result means that the value is valid.
 type Result struct {
-	BubbleErr     error
+	// BubbleErr aborts the whole validation and the error is returned
+	// as is from the `Validate()` method, same as `BubbleErr()` does.
+	BubbleErr error
+	// ValidationErr marks the field as invalid.
 	ValidationErr error
-	Skip          bool
+	// Warning is reported for the field but doesn't make it invalid.
+	Warning error
+	// Skip skips the remaining tags of the field,
+	// same as returning `ErrSkipFurther` from a `TagFunc`.
+	Skip bool
+	// Replace makes `Value` the value that the remaining
+	// tags of the field are validated against. The value is
+	// also set to the field if the field can be set.
+	Replace bool
+	Value   interface{}
+}
+
+// ResultFunc is a func that is used to validate a field `s`
+// using data providing in slice `o` and return a `Result`
+// describing how the validation should continue.
+//
+// It works the same as `TagFunc` otherwise.
+type ResultFunc func(s interface{}, o []interface{}) Result
+
+// resultFunc adapts a `TagFunc` to the `ResultFunc` signature,
+// converting `ErrSkipFurther` and bubbled errors to their `Result` counterparts.
+func resultFunc(fn TagFunc) ResultFunc {
+	return func(s interface{}, o []interface{}) Result {
+		err := fn(s, o)
+		if err == nil {
+			return Result{}
+		}
+		if errors.Is(err, ErrSkipFurther) {
+			return Result{Skip: true}
+		}
+
+		var b *bubbleErr
+		if errors.As(err, &b) {
+			return Result{BubbleErr: b.err}
+		}
+		return Result{ValidationErr: err}
+	}
 }
@@ -0,0 +1,445 @@
+package vali
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// rules map stores the tags that were added to struct fields
+// without struct tags, by the struct type and the field name.
+type rules map[reflect.Type]map[string][]tag
+
+// Number is a constraint that permits any number type.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// Rule is a validation rule of the fields of type `F`, it's the code
+// counterpart of a tag in a struct field tag. Rules of a different type
+// than the field they're added to don't compile.
+type Rule[F any] struct {
+	tags []tag
+	err  error
+}
+
+// Required returns the `required` rule.
+func Required[F any]() Rule[F] {
+	return newRule[F](requiredTag)
+}
+
+// RequiredWithout returns the `required_without` rule,
+// `field` is the name of the struct field it points to.
+func RequiredWithout[F any](field string) Rule[F] {
+	return newRule[F](requiredWithoutTag, fieldRef(field))
+}
+
+// Optional returns the `optional` rule.
+func Optional[F any]() Rule[F] {
+	return newRule[F](optionalTag)
+}
+
+// Min returns the `min` rule of numbers.
+func Min[F Number](n F) Rule[F] {
+	return newRule[F](minTag, normalizeArg(n))
+}
+
+// MinLen returns the `min` rule of strings, which compares their length.
+func MinLen[F ~string](n int) Rule[F] {
+	return newRule[F](minTag, normalizeArg(n))
+}
+
+// MinItems returns the `min` rule of slices, which compares their length.
+func MinItems[S ~[]E, E any](n int) Rule[S] {
+	return newRule[S](minTag, normalizeArg(n))
+}
+
+// MinTime returns the `min` rule of `time.Time` values.
+func MinTime(t time.Time) Rule[time.Time] {
+	return newRule[time.Time](minTag, t)
+}
+
+// Max returns the `max` rule of numbers.
+func Max[F Number](n F) Rule[F] {
+	return newRule[F](maxTag, normalizeArg(n))
+}
+
+// MaxLen returns the `max` rule of strings, which compares their length.
+func MaxLen[F ~string](n int) Rule[F] {
+	return newRule[F](maxTag, normalizeArg(n))
+}
+
+// MaxItems returns the `max` rule of slices, which compares their length.
+func MaxItems[S ~[]E, E any](n int) Rule[S] {
+	return newRule[S](maxTag, normalizeArg(n))
+}
+
+// MaxTime returns the `max` rule of `time.Time` values.
+func MaxTime(t time.Time) Rule[time.Time] {
+	return newRule[time.Time](maxTag, t)
+}
+
+// Eq returns the `eq` rule.
+func Eq[F comparable](val F) Rule[F] {
+	return newRule[F](eqTag, normalizeArg(val))
+}
+
+// Neq returns the `neq` rule.
+func Neq[F comparable](val F) Rule[F] {
+	return newRule[F](neqTag, normalizeArg(val))
+}
+
+// OneOf returns the `one_of` rule.
+func OneOf[F comparable](vals ...F) Rule[F] {
+	return newRule[F](oneofTag, normalizeArgs(vals)...)
+}
+
+// NoneOf returns the `none_of` rule.
+func NoneOf[F comparable](vals ...F) Rule[F] {
+	return newRule[F](noneofTag, normalizeArgs(vals)...)
+}
+
+// Dups returns the `dups` rule.
+func Dups[S ~[]E, E comparable]() Rule[S] {
+	return newRule[S](dupsTag)
+}
+
+// Default returns the `default` rule, the values are
+// converted the same way as the values of the `default` tag.
+func Default[F any](vals ...string) Rule[F] {
+	return newRule[F](defaultTag, normalizeArgs(vals)...)
+}
+
+// Each returns the `>` rule followed by the given rules,
+// which validate the elements of a slice.
+func Each[S ~[]E, E any](rules ...Rule[E]) Rule[S] {
+	r := newRule[S](dive)
+	for _, er := range rules {
+		r.tags = append(r.tags, er.tags...)
+		if r.err == nil {
+			r.err = er.err
+		}
+	}
+	return r
+}
+
+// Ptr returns the given rules for a pointer field,
+// the pointer is dereferenced the same way it is for struct tags.
+func Ptr[F any](rules ...Rule[F]) Rule[*F] {
+	r := Rule[*F]{}
+	for _, pr := range rules {
+		r.tags = append(r.tags, pr.tags...)
+		if r.err == nil {
+			r.err = pr.err
+		}
+	}
+	return r
+}
+
+// Tag returns a rule for any registered tag,
+// allowing to use custom tags with the rule builder.
+func Tag[F any](name string, args ...interface{}) Rule[F] {
+	return newRule[F](name, args...)
+}
+
+// Warn makes the failure of the rule a warning, same as the `warn:` prefix.
+func Warn[F any](r Rule[F]) Rule[F] {
+	tags := make([]tag, 0, len(r.tags))
+	for _, t := range r.tags {
+		t.severity = SeverityWarning
+		tags = append(tags, t)
+	}
+	r.tags = tags
+	return r
+}
+
+// Not inverts the outcome of the rule, same as `not(...)`.
+// Rules made of several tags have to fail as a whole.
+func Not[F any](r Rule[F]) Rule[F] {
+	if r.err != nil {
+		return r
+	}
+	if hasDive(r.tags) {
+		return Rule[F]{err: fmt.Errorf("rule '%s' validates the elements of a slice, it can't be negated", ruleName(r.tags))}
+	}
+	if len(r.tags) == 1 {
+		t := r.tags[0]
+		t.negate = !t.negate
+		return Rule[F]{tags: []tag{t}}
+	}
+
+	t := tag{name: ruleName(r.tags), args: make([]interface{}, 0), negate: true, alts: [][]tag{r.tags}}
+	return Rule[F]{tags: []tag{t}}
+}
+
+// Any returns a rule that passes if any of the given rules pass, same as `||`.
+func Any[F any](rules ...Rule[F]) Rule[F] {
+	t := tag{args: make([]interface{}, 0)}
+	names := make([]string, 0, len(rules))
+	for _, r := range rules {
+		if r.err != nil {
+			return r
+		}
+		if hasDive(r.tags) {
+			return Rule[F]{err: fmt.Errorf("rule '%s' validates the elements of a slice, it can't be an alternative", ruleName(r.tags))}
+		}
+		if len(r.tags) > 0 {
+			t.severity = r.tags[0].severity
+		}
+		names = append(names, ruleName(r.tags))
+		t.alts = append(t.alts, r.tags)
+	}
+	t.name = strings.Join(names, orSep)
+	return Rule[F]{tags: []tag{t}}
+}
+
+func newRule[F any](name string, args ...interface{}) Rule[F] {
+	return Rule[F]{
+		tags: []tag{{
+			name: name,
+			args: append(make([]interface{}, 0, len(args)), args...),
+		}},
+	}
+}
+
+// ruleName returns the name of the rule used in errors.
+func ruleName(tags []tag) string {
+	names := make([]string, 0, len(tags))
+	for _, t := range tags {
+		names = append(names, t.displayName())
+	}
+	return strings.Join(names, tagSep)
+}
+
+func hasDive(tags []tag) bool {
+	for _, t := range tags {
+		if t.name == dive {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeArg converts the argument to the types that
+// are used for the arguments parsed from a tag string.
+func normalizeArg(arg interface{}) interface{} {
+	v := reflect.ValueOf(arg)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.String:
+		return v.String()
+	default:
+		return arg
+	}
+}
+
+func normalizeArgs[V any](vals []V) []interface{} {
+	args := make([]interface{}, 0, len(vals))
+	for _, val := range vals {
+		args = append(args, normalizeArg(val))
+	}
+	return args
+}
+
+// StructRules is implemented by the rule sets
+// which can be registered using `SetRules`.
+type StructRules interface {
+	structRules() (reflect.Type, map[string][]tag, error)
+}
+
+// RuleSet holds the rules of the struct type `T`,
+// it's an alternative to struct tags which allows to validate
+// types that can't be tagged and checks the fields at compile time.
+type RuleSet[T any] struct {
+	fields map[string][]tag
+	err    error
+}
+
+// Rules returns a new rule set for the struct type `T`,
+// the rules of its fields are added with `Field`.
+// Example:
+/*
+
+	rs := vali.Rules[User]()
+	vali.Field(rs, func(u *User) *string { return &u.Name }, vali.Required[string](), vali.MaxLen[string](64))
+	vali.Field(rs, func(u *User) *int { return &u.Age }, vali.Min(18))
+	vali.Field(rs, func(u *User) *[]string { return &u.Tags }, vali.Each[[]string](vali.OneOf("a", "b")))
+
+	err := v.SetRules(rs)
+
+*/
+func Rules[T any]() *RuleSet[T] {
+	rs := &RuleSet[T]{
+		fields: map[string][]tag{},
+	}
+
+	var zero T
+	if reflect.TypeOf(&zero).Elem().Kind() != reflect.Struct {
+		rs.err = fmt.Errorf("rules can only be set for structs; got %s", reflect.TypeOf(&zero).Elem().Kind())
+	}
+	return rs
+}
+
+// Field adds the rules to the field of `T` returned by `sel`,
+// the rules have to be of the same type as the field.
+// The `sel` func has to return a pointer to an exported field of `T`.
+// The rules are validated in order, after the tags of the field.
+func Field[T, F any](rs *RuleSet[T], sel func(*T) *F, rules ...Rule[F]) *RuleSet[T] {
+	if rs.err != nil {
+		return rs
+	}
+	if sel == nil {
+		rs.err = errors.New("field selector is nil")
+		return rs
+	}
+
+	name, err := selectField(func(t *T) interface{} { return sel(t) })
+	if err != nil {
+		rs.err = err
+		return rs
+	}
+
+	for _, r := range rules {
+		if r.err != nil {
+			rs.err = fmt.Errorf("field '%s': %w", name, r.err)
+			return rs
+		}
+		rs.fields[name] = append(rs.fields[name], r.tags...)
+	}
+	return rs
+}
+
+func (rs *RuleSet[T]) structRules() (reflect.Type, map[string][]tag, error) {
+	var zero T
+	return reflect.TypeOf(&zero).Elem(), rs.fields, rs.err
+}
+
+// selectField returns the name of the field of `T` that `sel` points to.
+func selectField[T any](sel func(*T) interface{}) (string, error) {
+	if sel == nil {
+		return "", errors.New("field selector is nil")
+	}
+
+	s := new(T)
+	ptr := reflect.ValueOf(sel(s))
+	if ptr.Kind() != reflect.Ptr || ptr.IsNil() {
+		return "", fmt.Errorf("field selector has to return a pointer to a field; got %s", ptr.Kind())
+	}
+
+	val := reflect.ValueOf(s).Elem()
+	// Fields of embedded structs can be selected as they're promoted
+	for _, f := range reflect.VisibleFields(val.Type()) {
+		fv, ok := fieldByIndex(val, f.Index)
+		if !ok || fv.UnsafeAddr() != ptr.Pointer() || f.Type != ptr.Elem().Type() {
+			continue
+		}
+		if f.PkgPath != "" {
+			return "", fmt.Errorf("field '%s' is not exported", f.Name)
+		}
+		return f.Name, nil
+	}
+
+	return "", fmt.Errorf("field selector has to return a pointer to a field of %s", val.Type())
+}
+
+// SetRules registers the rules of a rule set created with `Rules`.
+// The rules are compiled in to the same plan as the struct tags,
+// so they're validated as if they were added to the struct tags
+// of the fields. Setting the rules of a field again replaces them.
+func (v *Vali) SetRules(rs StructRules) error {
+	if rs == nil {
+		return errors.New("rules are nil")
+	}
+
+	typ, fields, err := rs.structRules()
+	if err != nil {
+		return err
+	}
+
+	v.setRules(typ, fields)
+	return nil
+}
+
+// RegisterStructRules registers tag strings for the fields of a struct,
+// which is useful for structs that can't be tagged, like generated ones.
+// The keys of the map are the field names and the values use the
+// same syntax as the struct tags. The rules behave exactly the same
+// as struct tags and are validated after the tags the fields already have.
+// Registering the rules of a field again replaces them.
+// Example:
+/*
+
+	err := v.RegisterStructRules(&pb.Order{}, map[string]string{
+		"Id":    "required",
+		"Items": "required|>|required",
+	})
+
+*/
+func (v *Vali) RegisterStructRules(typ interface{}, fields map[string]string) error {
+	t, err := structType(typ)
+	if err != nil {
+		return err
+	}
+
+	m := make(map[string][]tag, len(fields))
+	for name, tg := range fields {
+		if _, err := v.ruleField(t, name); err != nil {
+			return err
+		}
+		m[name] = parseTags(tg)
+	}
+
+	v.setRules(t, m)
+	return nil
+}
+
+// ruleField returns the field of the struct which rules can be set,
+// the fields of flattened embedded structs are included.
+func (v *Vali) ruleField(typ reflect.Type, name string) (reflect.StructField, error) {
+	if f, ok := v.visibleField(typ, name); ok {
+		return f, nil
+	}
+	if f, ok := typ.FieldByName(name); ok && len(f.Index) == 1 && f.PkgPath != "" {
+		return reflect.StructField{}, fmt.Errorf("field '%s' is not exported", name)
+	}
+	return reflect.StructField{}, fmt.Errorf("%s has no field '%s'", typ, name)
+}
+
+func (v *Vali) setRules(typ reflect.Type, fields map[string][]tag) {
+	m := v.rules[typ]
+	if m == nil {
+		m = make(map[string][]tag, len(fields))
+	}
+	for name, tags := range fields {
+		m[name] = append([]tag(nil), tags...)
+	}
+
+	v.rules[typ] = m
+	v.resetPlans()
+}
+
+// structType returns the struct type of the given value,
+// pointers are dereferenced.
+func structType(typ interface{}) (reflect.Type, error) {
+	if typ == nil {
+		return nil, errors.New("type is nil")
+	}
+
+	t := reflect.TypeOf(typ)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("rules can only be set for structs; got %s", t.Kind())
+	}
+	return t, nil
+}
@@ -0,0 +1,226 @@
+package vali
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type rulesAge int
+
+type rulesUser struct {
+	Name  string
+	Email string `vali:"required"`
+	Age   rulesAge
+	Tags  []string
+	Role  string
+	Alt   string
+	Limit *uint
+	priv  string
+}
+
+func TestSetRules(t *testing.T) {
+	limit := uint(11)
+	v := New()
+	rs := Rules[rulesUser]()
+	Field(rs, func(u *rulesUser) *string { return &u.Name }, Required[string](), MaxLen[string](5))
+	Field(rs, func(u *rulesUser) *string { return &u.Email }, NoneOf("root@a"))
+	Field(rs, func(u *rulesUser) *rulesAge { return &u.Age }, Min[rulesAge](18))
+	Field(rs, func(u *rulesUser) *[]string { return &u.Tags }, Each[[]string](OneOf("a", "b")))
+	Field(rs, func(u *rulesUser) *string { return &u.Role }, Warn(Eq("admin")), Any(Eq("user"), Not(Tag[string](requiredTag))))
+	Field(rs, func(u *rulesUser) *string { return &u.Alt }, RequiredWithout[string]("Name"))
+	Field(rs, func(u *rulesUser) **uint { return &u.Limit }, Ptr(Optional[uint](), Max[uint](10)))
+	if err := v.SetRules(rs); err != nil {
+		t.Fatalf("Vali.SetRules() error = %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		s            interface{}
+		want         error
+		wantWarnings []error
+	}{
+		{
+			name:         "valid struct, should not error",
+			s:            &rulesUser{Name: "a", Email: "a@a", Age: 19, Tags: []string{"a"}, Role: "user"},
+			want:         nil,
+			wantWarnings: []error{newTagError("Role", eqTag, errors.New("user is not equal to admin"))},
+		},
+		{
+			name: "rules fail, should error the same as tags",
+			s:    &rulesUser{Name: "abcdef", Email: "root@a", Age: 1, Tags: []string{"c"}, Role: "admin", Limit: &limit},
+			want: newAggErr().addErr(
+				newTagError("Name", maxTag, errors.New("abcdef is more than 5")),
+				newTagError("Email", noneofTag, errors.New("must have none of [root@a]")),
+				newTagError("Age", minTag, errors.New("1 is less than 18")),
+				withPath(newTagError("Tags", oneofTag, errors.New("must have at least one of [a b]")), "Tags[0]"),
+				newTagError("Role", "eq||not(required)", &OrErr{Sl: []error{
+					newTagError("", eqTag, errors.New("admin is not equal to user")),
+					newTagError("", "not(required)", errors.New("must not pass 'required' tag")),
+				}}),
+				newTagError("Limit", maxTag, errors.New("11 is more than 10")),
+			),
+			wantWarnings: []error{},
+		},
+		{
+			name: "struct tags are validated before the rules",
+			s:    &rulesUser{Age: 19},
+			want: newAggErr().addErr(
+				newTagError("Name", requiredTag, errors.New("empty string")),
+				newTagError("Email", requiredTag, errors.New("empty string")),
+				newTagError("Alt", requiredWithoutTag, errors.New("empty string")),
+			),
+			wantWarnings: []error{newTagError("Role", eqTag, errors.New(" is not equal to admin"))},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := v.ValidateReport(tt.s)
+			if got := r.Err(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Vali.ValidateReport() = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(r.Warnings.Sl, tt.wantWarnings) {
+				t.Errorf("Vali.ValidateReport() warnings = %v, want %v", r.Warnings.Sl, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestRulesErrors(t *testing.T) {
+	v := New()
+	tests := []struct {
+		name string
+		rs   StructRules
+	}{
+		{
+			name: "nil rules, should error",
+			rs:   nil,
+		},
+		{
+			name: "not a struct, should error",
+			rs:   Rules[int](),
+		},
+		{
+			name: "selector is nil, should error",
+			rs:   Field[rulesUser, string](Rules[rulesUser](), nil, Required[string]()),
+		},
+		{
+			name: "selector returns nil, should error",
+			rs:   Field(Rules[rulesUser](), func(u *rulesUser) *string { return nil }, Required[string]()),
+		},
+		{
+			name: "selector returns a pointer to a different value, should error",
+			rs:   Field(Rules[rulesUser](), func(u *rulesUser) *string { return new(string) }, Required[string]()),
+		},
+		{
+			name: "selector returns an unexported field, should error",
+			rs:   Field(Rules[rulesUser](), func(u *rulesUser) *string { return &u.priv }, Required[string]()),
+		},
+		{
+			name: "slice rule is negated, should error",
+			rs:   Field(Rules[rulesUser](), func(u *rulesUser) *[]string { return &u.Tags }, Not(Each[[]string](Eq("a")))),
+		},
+		{
+			name: "slice rule is an alternative, should error",
+			rs:   Field(Rules[rulesUser](), func(u *rulesUser) *[]string { return &u.Tags }, Any(Each[[]string](Eq("a")), Required[[]string]())),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := v.SetRules(tt.rs); err == nil {
+				t.Error("Vali.SetRules() expected an error")
+			}
+		})
+	}
+}
+
+func TestRegisterStructRules(t *testing.T) {
+	type Item struct {
+		ID int `vali:"min=0"`
+	}
+	type order struct {
+		ID    string
+		Items []int `vali:"optional"`
+		Note  string
+		Item  *Item
+		priv  string
+	}
+
+	v := New()
+	tests := []struct {
+		name    string
+		typ     interface{}
+		fields  map[string]string
+		wantErr bool
+	}{
+		{
+			name:    "nil type, should error",
+			typ:     nil,
+			fields:  map[string]string{},
+			wantErr: true,
+		},
+		{
+			name:    "not a struct, should error",
+			typ:     1,
+			fields:  map[string]string{},
+			wantErr: true,
+		},
+		{
+			name:    "unknown field, should error",
+			typ:     &order{},
+			fields:  map[string]string{"Unknown": "required"},
+			wantErr: true,
+		},
+		{
+			name:    "unexported field, should error",
+			typ:     &order{},
+			fields:  map[string]string{"priv": "required"},
+			wantErr: true,
+		},
+		{
+			name: "valid rules, should not error",
+			typ:  &order{},
+			fields: map[string]string{
+				"ID":    "required|warn:max=3",
+				"Items": "min=1|>|one_of=1,2",
+				"Note":  "optional|max=2",
+				"Item":  "required",
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := v.RegisterStructRules(tt.typ, tt.fields); (err != nil) != tt.wantErr {
+				t.Errorf("Vali.RegisterStructRules() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("rules should behave as struct tags", func(t *testing.T) {
+		s := &order{ID: "abcd", Items: []int{1, 3}, Item: &Item{ID: -1}}
+		r := v.ValidateReport(s)
+		want := newAggErr().addErr(
+			withPath(newTagError("Items", oneofTag, errors.New("must have at least one of [1 2]")), "Items[1]"),
+			newAggErr().addErr(withPath(newTagError("ID", minTag, errors.New("-1 is less than 0")), "Item.ID")),
+		)
+		if got := r.Err(); !reflect.DeepEqual(got, want) {
+			t.Errorf("Vali.ValidateReport() = %v, want %v", got, want)
+		}
+		wantWarnings := []error{newTagError("ID", maxTag, errors.New("abcd is more than 3"))}
+		if !reflect.DeepEqual(r.Warnings.Sl, wantWarnings) {
+			t.Errorf("Vali.ValidateReport() warnings = %v, want %v", r.Warnings.Sl, wantWarnings)
+		}
+	})
+
+	t.Run("merged with struct tags, should report tag conflicts", func(t *testing.T) {
+		if err := v.RegisterStructRules(order{}, map[string]string{"Items": "required"}); err != nil {
+			t.Fatal(err)
+		}
+		want := newAggErr().addErr(errors.New("a field can only have one of: optional, required, required_without"))
+		if got := v.Validate(&order{ID: "a", Item: &Item{ID: 1}}); !reflect.DeepEqual(got, want) {
+			t.Errorf("Vali.Validate() = %v, want %v", got, want)
+		}
+	})
+}
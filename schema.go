@@ -0,0 +1,392 @@
+package vali
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// schemaDraft is the JSON Schema dialect of the generated schemas.
+const schemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// Schema is a JSON Schema object, it can be encoded with `encoding/json`.
+type Schema map[string]interface{}
+
+// SchemaFunc is a func that returns the JSON Schema keywords of a tag
+// using the tag arguments in slice `o`, the keywords are added to the schema of the field.
+// `typ` is the type of the validated value, pointers are dereferenced.
+//
+// Nil can be returned if the tag can't be expressed in JSON Schema.
+type SchemaFunc func(typ reflect.Type, o []interface{}) Schema
+
+// schemas map stores the funcs that describe the tags in JSON Schema.
+type schemas map[string]SchemaFunc
+
+// SetTagSchema allows to describe a tag in the generated JSON Schemas,
+// tags without a `SchemaFunc` are left out of the schemas.
+// Current func that has the same tag name will get over written.
+// Example:
+/*
+
+	v.SetTagSchema("email", func(typ reflect.Type, o []interface{}) vali.Schema {
+		return vali.Schema{"format": "email"}
+	})
+
+*/
+func (v *Vali) SetTagSchema(tag string, fn SchemaFunc) {
+	if fn == nil || tag == "" {
+		return
+	}
+
+	v.schemas[tag] = fn
+}
+
+// JSONSchema returns a Draft 2020-12 JSON Schema of the struct type `typ`
+// built from the tags of its fields, the same tags `Validate` uses.
+// Properties are named by the `json` tags of the fields and
+// nested named structs are added to `$defs` and referenced with `$ref`.
+//
+// Only the tags that are validated by `Validate` are used,
+// so tags with groups, warnings and tags which point to other fields
+// (except `required_without`) are left out.
+// Example:
+/*
+
+	schema, err := vali.JSONSchema(v, reflect.TypeOf(User{}))
+	...
+	b, err := json.Marshal(schema)
+
+*/
+func JSONSchema(v *Vali, typ reflect.Type) (Schema, error) {
+	typ = derefType(typ)
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("function only accepts struct types; got %v", typ)
+	}
+
+	b := newSchemaBuilder(v, "#/$defs/")
+	// The root schema can be referenced by the nested structs.
+	b.refs[typ] = "#"
+
+	s, err := b.object(typ)
+	if err != nil {
+		return nil, err
+	}
+
+	s["$schema"] = schemaDraft
+	if len(b.defs) > 0 {
+		s["$defs"] = b.defs
+	}
+	return s, nil
+}
+
+// schemaBuilder builds the schemas of struct types,
+// nested named structs are added to `defs`.
+type schemaBuilder struct {
+	v  *Vali
+	ps *plans
+	// prefix is added to the names of the `defs` to reference them.
+	prefix string
+	defs   map[string]Schema
+	refs   map[reflect.Type]string
+}
+
+func newSchemaBuilder(v *Vali, prefix string) *schemaBuilder {
+	return &schemaBuilder{
+		v:      v,
+		ps:     v.loadPlans(),
+		prefix: prefix,
+		defs:   map[string]Schema{},
+		refs:   map[reflect.Type]string{},
+	}
+}
+
+// ref returns the reference to the schema of the named struct type,
+// adding the schema to `defs` if it wasn't added before.
+func (b *schemaBuilder) ref(typ reflect.Type) (Schema, error) {
+	if ref, ok := b.refs[typ]; ok {
+		return Schema{"$ref": ref}, nil
+	}
+
+	name := b.defName(typ)
+	b.refs[typ] = b.prefix + name
+	// The name is taken before the schema is built,
+	// so recursive types get a reference to themselves.
+	b.defs[name] = nil
+	s, err := b.object(typ)
+	if err != nil {
+		return nil, err
+	}
+	b.defs[name] = s
+
+	return Schema{"$ref": b.prefix + name}, nil
+}
+
+// defName returns a unique name for the type in `defs`,
+// types with the same name are prefixed with their package path.
+func (b *schemaBuilder) defName(typ reflect.Type) string {
+	name := schemaName(typ.Name())
+	if _, ok := b.defs[name]; !ok {
+		return name
+	}
+
+	return schemaName(strings.ReplaceAll(typ.PkgPath(), "/", ".") + "." + typ.Name())
+}
+
+// schemaName replaces the characters that are not allowed in schema names.
+func schemaName(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+			return r
+		}
+		return '_'
+	}, s)
+}
+
+// object returns the schema of the struct type with its properties.
+func (b *schemaBuilder) object(typ reflect.Type) (Schema, error) {
+	p := b.v.plan(b.ps, typ)
+	fps := map[string]fieldPlan{}
+	for _, fp := range p.fields {
+		if fp.err != nil {
+			return nil, fp.err
+		}
+		fps[fp.field.Name] = fp
+	}
+
+	// Fields of flattened embedded structs are properties of the struct itself
+	names := map[string]string{}
+	for _, f := range p.visible {
+		if name, ok := jsonName(f); ok {
+			names[f.Name] = name
+		}
+	}
+
+	props := Schema{}
+	required := make([]string, 0)
+	anyOf := make([]Schema, 0)
+	for _, f := range p.visible {
+		// Ignore fields left out of JSON
+		if _, ok := names[f.Name]; !ok {
+			continue
+		}
+
+		s, err := b.typeSchema(f.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		name := names[f.Name]
+		for _, t := range fps[f.Name].tags {
+			if !b.applies(t) || t.negate {
+				continue
+			}
+
+			switch t.name {
+			case requiredTag:
+				required = append(required, name)
+			case requiredWithoutTag:
+				without := make([]string, 0, len(t.args))
+				for _, arg := range t.args {
+					if r, ok := arg.(fieldRef); ok && names[string(r)] != "" {
+						without = append(without, names[string(r)])
+					}
+				}
+				if len(without) == 0 {
+					continue
+				}
+				anyOf = append(anyOf, Schema{"anyOf": []Schema{{"required": []string{name}}, {"required": without}}})
+			}
+		}
+
+		if err := b.applyTags(s, f.Type, fps[f.Name].tags); err != nil {
+			return nil, fmt.Errorf("field: '%s', %w", f.Name, err)
+		}
+		props[name] = s
+	}
+
+	s := Schema{
+		"type":       "object",
+		"properties": props,
+	}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	switch len(anyOf) {
+	case 0:
+	case 1:
+		s["anyOf"] = anyOf[0]["anyOf"]
+	default:
+		s["allOf"] = anyOf
+	}
+	return s, nil
+}
+
+// typeSchema returns the schema of the JSON value the type is encoded to.
+func (b *schemaBuilder) typeSchema(typ reflect.Type) (Schema, error) {
+	typ = derefType(typ)
+	switch {
+	case typ == timeType:
+		return Schema{"type": "string", "format": "date-time"}, nil
+	case typ.Implements(textMarshalerType) || reflect.PtrTo(typ).Implements(textMarshalerType):
+		return Schema{"type": "string"}, nil
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		return Schema{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Schema{"type": "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return Schema{"type": "number"}, nil
+	case reflect.String:
+		return Schema{"type": "string"}, nil
+	case reflect.Slice, reflect.Array:
+		// Byte slices are encoded as base64 strings
+		if typ.Kind() == reflect.Slice && typ.Elem().Kind() == reflect.Uint8 {
+			return Schema{"type": "string", "contentEncoding": "base64"}, nil
+		}
+		items, err := b.typeSchema(typ.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return Schema{"type": "array", "items": items}, nil
+	case reflect.Map:
+		props, err := b.typeSchema(typ.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return Schema{"type": "object", "additionalProperties": props}, nil
+	case reflect.Struct:
+		if typ.Name() == "" {
+			return b.object(typ)
+		}
+		return b.ref(typ)
+	}
+
+	// Interfaces can hold any value
+	return Schema{}, nil
+}
+
+// applies reports whether the tag is always validated as an error.
+func (b *schemaBuilder) applies(t tag) bool {
+	return len(t.groups) == 0 && b.v.severity(t) == SeverityError
+}
+
+// applyTags adds the keywords of the tags to the schema of the type,
+// the tags after a dive are added to the schema of the items.
+func (b *schemaBuilder) applyTags(s Schema, typ reflect.Type, tgs []tag) error {
+	typ = derefType(typ)
+	for i, t := range tgs {
+		if t.name == dive {
+			items, ok := s["items"].(Schema)
+			if !ok {
+				return errors.New("dive is only supported for slices and arrays")
+			}
+			return b.applyTags(items, typ.Elem(), tgs[i+1:])
+		}
+		if !b.applies(t) {
+			continue
+		}
+
+		if t.name == defaultTag && !t.negate {
+			if def, ok := defaultValue(typ, t.args); ok {
+				s["default"] = def
+			}
+			continue
+		}
+
+		if frag := b.tagSchema(typ, t); len(frag) > 0 {
+			mergeSchema(s, frag)
+		}
+	}
+
+	return nil
+}
+
+// tagSchema returns the keywords of a single tag or nil
+// if the tag can't be expressed in JSON Schema.
+func (b *schemaBuilder) tagSchema(typ reflect.Type, t tag) Schema {
+	if len(t.alts) > 0 {
+		anyOf := make([]Schema, 0, len(t.alts))
+		for _, alt := range t.alts {
+			s := Schema{}
+			for _, at := range alt {
+				frag := b.tagSchema(typ, at)
+				// An alternative which can't be expressed could pass
+				// any value, so the whole group can't be expressed.
+				if len(frag) == 0 {
+					return nil
+				}
+				mergeSchema(s, frag)
+			}
+			anyOf = append(anyOf, s)
+		}
+		return Schema{"anyOf": anyOf}
+	}
+
+	fn, ok := b.v.schemas[t.name]
+	if !ok || t.hasRefs() {
+		return nil
+	}
+	frag := fn(typ, t.args)
+	if len(frag) == 0 {
+		return nil
+	}
+
+	if t.negate {
+		return Schema{"not": frag}
+	}
+	return frag
+}
+
+// mergeSchema adds the keywords of `frag` to `s`,
+// if any of the keywords are already set `frag` is added to `allOf`.
+func mergeSchema(s, frag Schema) {
+	for k := range frag {
+		if _, ok := s[k]; ok {
+			allOf, _ := s["allOf"].([]Schema)
+			s["allOf"] = append(allOf, frag)
+			return
+		}
+	}
+
+	for k, val := range frag {
+		s[k] = val
+	}
+}
+
+// defaultValue returns the JSON value of the `default` tag arguments.
+func defaultValue(typ reflect.Type, args []interface{}) (interface{}, bool) {
+	if len(args) == 0 {
+		return nil, false
+	}
+
+	vals := make([]string, 0, len(args))
+	for _, arg := range args {
+		vals = append(vals, GetString(arg))
+	}
+
+	val := reflect.New(typ).Elem()
+	if err := setValue(val, vals); err != nil {
+		return nil, false
+	}
+	return val.Interface(), true
+}
+
+// derefType returns the type pointed to by the pointer types.
+func derefType(typ reflect.Type) reflect.Type {
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ
+}
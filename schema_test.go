@@ -0,0 +1,167 @@
+package vali
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type schemaAddress struct {
+	City string `json:"city" vali:"required|one_of=Vilnius,Kaunas"`
+}
+
+type schemaNode struct {
+	Name     string        `json:"name" vali:"required"`
+	Children []*schemaNode `json:"children"`
+}
+
+type schemaUser struct {
+	Name     string            `json:"name" vali:"required|max=65"`
+	Nick     string            `json:"nick,omitempty" vali:"required_without=*Name"`
+	Age      int               `json:"age" vali:"min=17|max=130"`
+	Score    float64           `json:"score" vali:"warn:max=5.5|eq=1.5||eq=2.5"`
+	Roles    []string          `json:"roles" vali:"min=0|max=5|dups|>|not(one_of=root)"`
+	Status   string            `json:"status" vali:"default=new|neq@update=old|email"`
+	Created  time.Time         `json:"created"`
+	Timeout  time.Duration     `json:"timeout" vali:"default=1s"`
+	Address  *schemaAddress    `json:"address" vali:"required"`
+	Previous []schemaAddress   `json:"previous"`
+	Labels   map[string]string `json:"labels"`
+	Raw      []byte            `json:"raw"`
+	Secret   string            `json:"-" vali:"required"`
+	hidden   string
+}
+
+type schemaEmbedded struct {
+	schemaAddress
+	Zip string `json:"zip" vali:"required"`
+}
+
+func TestJSONSchema(t *testing.T) {
+	v := New()
+	v.RegisterGroups("update")
+	v.SetTagSchema("email", func(typ reflect.Type, o []interface{}) Schema {
+		return Schema{"format": "email"}
+	})
+
+	tests := []struct {
+		name    string
+		typ     reflect.Type
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "tags mapped to keywords, should not error",
+			typ:  reflect.TypeOf(&schemaUser{}),
+			want: `{
+				"$schema": "https://json-schema.org/draft/2020-12/schema",
+				"type": "object",
+				"properties": {
+					"name": {"type": "string", "maxLength": 64},
+					"nick": {"type": "string"},
+					"age": {"type": "integer", "exclusiveMinimum": 17, "maximum": 130},
+					"score": {"type": "number", "anyOf": [{"const": 1.5}, {"const": 2.5}]},
+					"roles": {"type": "array", "minItems": 1, "maxItems": 5, "uniqueItems": true, "items": {"type": "string", "not": {"enum": ["root"]}}},
+					"status": {"type": "string", "default": "new", "format": "email"},
+					"created": {"type": "string", "format": "date-time"},
+					"timeout": {"type": "integer", "default": 1000000000},
+					"address": {"$ref": "#/$defs/schemaAddress"},
+					"previous": {"type": "array", "items": {"$ref": "#/$defs/schemaAddress"}},
+					"labels": {"type": "object", "additionalProperties": {"type": "string"}},
+					"raw": {"type": "string", "contentEncoding": "base64"}
+				},
+				"required": ["name", "address"],
+				"anyOf": [{"required": ["nick"]}, {"required": ["name"]}],
+				"$defs": {
+					"schemaAddress": {
+						"type": "object",
+						"properties": {
+							"city": {"type": "string", "enum": ["Vilnius", "Kaunas"]}
+						},
+						"required": ["city"]
+					}
+				}
+			}`,
+		},
+		{
+			name: "recursive type, should not error",
+			typ:  reflect.TypeOf(schemaNode{}),
+			want: `{
+				"$schema": "https://json-schema.org/draft/2020-12/schema",
+				"type": "object",
+				"properties": {
+					"name": {"type": "string"},
+					"children": {"type": "array", "items": {"$ref": "#"}}
+				},
+				"required": ["name"]
+			}`,
+		},
+		{
+			name: "embedded struct, should flatten its properties",
+			typ:  reflect.TypeOf(schemaEmbedded{}),
+			want: `{
+				"$schema": "https://json-schema.org/draft/2020-12/schema",
+				"type": "object",
+				"properties": {
+					"city": {"type": "string", "enum": ["Vilnius", "Kaunas"]},
+					"zip": {"type": "string"}
+				},
+				"required": ["city", "zip"]
+			}`,
+		},
+		{
+			name: "invalid tags, should error",
+			typ: reflect.TypeOf(struct {
+				Name string `vali:"required|optional"`
+			}{}),
+			wantErr: true,
+		},
+		{
+			name: "dive on a field that's not a slice, should error",
+			typ: reflect.TypeOf(struct {
+				Name string `vali:">|required"`
+			}{}),
+			wantErr: true,
+		},
+		{
+			name:    "not a struct, should error",
+			typ:     reflect.TypeOf(""),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := JSONSchema(v, tt.typ)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("JSONSchema() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if !jsonEqual(t, got, tt.want) {
+				b, _ := json.Marshal(got)
+				t.Errorf("JSONSchema() = %s, want %s", b, tt.want)
+			}
+		})
+	}
+}
+
+// jsonEqual reports whether the value is encoded to the same JSON as `want`.
+func jsonEqual(t *testing.T, got interface{}, want string) bool {
+	t.Helper()
+	b, err := json.Marshal(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var g, w interface{}
+	if err := json.Unmarshal(b, &g); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(want), &w); err != nil {
+		t.Fatal(err)
+	}
+	return reflect.DeepEqual(g, w)
+}
@@ -0,0 +1,141 @@
+package vali
+
+import "reflect"
+
+func minSchema(typ reflect.Type, o []interface{}) Schema {
+	if len(o) == 0 {
+		return nil
+	}
+
+	switch typ.Kind() {
+	case reflect.String:
+		n, ok := GetInt(o[0])
+		if !ok {
+			return nil
+		}
+		return Schema{"minLength": n}
+	case reflect.Slice, reflect.Array:
+		// The length of a slice has to be more than the argument.
+		n, ok := GetUIntFallback(o[0])
+		if !ok {
+			return nil
+		}
+		return Schema{"minItems": n + 1}
+	}
+
+	// Numbers have to be more than the argument.
+	n, ok := schemaValue(typ, o[0])
+	if !ok || typ == durationType {
+		return nil
+	}
+	return Schema{"exclusiveMinimum": n}
+}
+
+func maxSchema(typ reflect.Type, o []interface{}) Schema {
+	if len(o) == 0 {
+		return nil
+	}
+
+	switch typ.Kind() {
+	case reflect.String:
+		// The length of a string has to be less than the argument.
+		n, ok := GetInt(o[0])
+		if !ok || n < 1 {
+			return nil
+		}
+		return Schema{"maxLength": n - 1}
+	case reflect.Slice, reflect.Array:
+		n, ok := GetUIntFallback(o[0])
+		if !ok {
+			return nil
+		}
+		return Schema{"maxItems": n}
+	}
+
+	n, ok := schemaValue(typ, o[0])
+	if !ok || typ == durationType {
+		return nil
+	}
+	return Schema{"maximum": n}
+}
+
+func oneofSchema(typ reflect.Type, o []interface{}) Schema {
+	enum, ok := schemaValues(typ, o)
+	if !ok {
+		return nil
+	}
+	return Schema{"enum": enum}
+}
+
+func noneofSchema(typ reflect.Type, o []interface{}) Schema {
+	enum, ok := schemaValues(typ, o)
+	if !ok {
+		return nil
+	}
+	return Schema{"not": Schema{"enum": enum}}
+}
+
+func eqSchema(typ reflect.Type, o []interface{}) Schema {
+	if len(o) == 0 {
+		return nil
+	}
+
+	val, ok := schemaValue(typ, o[0])
+	if !ok {
+		return nil
+	}
+	return Schema{"const": val}
+}
+
+func neqSchema(typ reflect.Type, o []interface{}) Schema {
+	if len(o) == 0 {
+		return nil
+	}
+
+	val, ok := schemaValue(typ, o[0])
+	if !ok {
+		return nil
+	}
+	return Schema{"not": Schema{"const": val}}
+}
+
+func dupsSchema(typ reflect.Type, o []interface{}) Schema {
+	switch typ.Kind() {
+	case reflect.Slice, reflect.Array:
+		return Schema{"uniqueItems": true}
+	}
+	return nil
+}
+
+// schemaValues converts all of the tag arguments to JSON values of the type.
+func schemaValues(typ reflect.Type, o []interface{}) ([]interface{}, bool) {
+	if len(o) == 0 {
+		return nil, false
+	}
+
+	vals := make([]interface{}, 0, len(o))
+	for _, arg := range o {
+		val, ok := schemaValue(typ, arg)
+		if !ok {
+			return nil, false
+		}
+		vals = append(vals, val)
+	}
+	return vals, true
+}
+
+// schemaValue converts a tag argument to a JSON value of the type,
+// the same way the argument is compared to the values of the type.
+func schemaValue(typ reflect.Type, arg interface{}) (interface{}, bool) {
+	switch typ.Kind() {
+	case reflect.String:
+		return GetString(arg), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return GetInt(arg)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return GetUIntFallback(arg)
+	case reflect.Float32, reflect.Float64:
+		return GetFloat(arg)
+	}
+	return nil, false
+}
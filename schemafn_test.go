@@ -0,0 +1,107 @@
+package vali
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTagSchemas(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   SchemaFunc
+		typ  reflect.Type
+		args []interface{}
+		want Schema
+	}{
+		{
+			name: "min of a string",
+			fn:   minSchema,
+			typ:  reflect.TypeOf(""),
+			args: []interface{}{int64(3)},
+			want: Schema{"minLength": int64(3)},
+		},
+		{
+			name: "min of a uint",
+			fn:   minSchema,
+			typ:  reflect.TypeOf(uint(0)),
+			args: []interface{}{int64(3)},
+			want: Schema{"exclusiveMinimum": uint64(3)},
+		},
+		{
+			name: "min of a float with an int argument",
+			fn:   minSchema,
+			typ:  reflect.TypeOf(0.0),
+			args: []interface{}{int64(3)},
+			want: nil,
+		},
+		{
+			name: "min of a time",
+			fn:   minSchema,
+			typ:  reflect.TypeOf(time.Time{}),
+			args: []interface{}{"2020"},
+			want: nil,
+		},
+		{
+			name: "max of a string",
+			fn:   maxSchema,
+			typ:  reflect.TypeOf(""),
+			args: []interface{}{int64(3)},
+			want: Schema{"maxLength": int64(2)},
+		},
+		{
+			name: "max of an array",
+			fn:   maxSchema,
+			typ:  reflect.TypeOf([2]int{}),
+			args: []interface{}{int64(3)},
+			want: Schema{"maxItems": uint64(3)},
+		},
+		{
+			name: "max of a duration",
+			fn:   maxSchema,
+			typ:  reflect.TypeOf(time.Second),
+			args: []interface{}{int64(3)},
+			want: nil,
+		},
+		{
+			name: "one_of of a string with numbers",
+			fn:   oneofSchema,
+			typ:  reflect.TypeOf(""),
+			args: []interface{}{int64(1), "a"},
+			want: Schema{"enum": []interface{}{"1", "a"}},
+		},
+		{
+			name: "none_of of an int with a string",
+			fn:   noneofSchema,
+			typ:  reflect.TypeOf(0),
+			args: []interface{}{int64(1), "a"},
+			want: nil,
+		},
+		{
+			name: "neq of an int",
+			fn:   neqSchema,
+			typ:  reflect.TypeOf(0),
+			args: []interface{}{int64(1)},
+			want: Schema{"not": Schema{"const": int64(1)}},
+		},
+		{
+			name: "eq without arguments",
+			fn:   eqSchema,
+			typ:  reflect.TypeOf(0),
+			want: nil,
+		},
+		{
+			name: "dups of a string",
+			fn:   dupsSchema,
+			typ:  reflect.TypeOf(""),
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.fn(tt.typ, tt.args); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SchemaFunc() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
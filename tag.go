@@ -5,70 +5,261 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"unicode"
 )
 
 type tag struct {
-	name string
-	args []interface{}
+	name     string
+	args     []interface{}
+	severity Severity
+	// groups holds the validation groups of the tag,
+	// tags without groups are always validated.
+	groups []string
+	// alias is the name of the alias the tag was expanded from.
+	alias string
+	// negate inverts the outcome of the tag.
+	negate bool
+	// alts holds the alternatives of an OR group, the tag passes
+	// if all the tags of any of the alternatives pass.
+	alts [][]tag
+	// err is the syntax error of the tag,
+	// it's returned when the tags of a field are checked.
+	err error
 }
 
+// fieldRef is a tag argument which points to another struct field,
+// it's resolved to the value of that field before the tag is validated.
+type fieldRef string
+
+// refFunc resolves the value of a field pointed to with the `pointerToField` sign.
+type refFunc func(name string) (interface{}, bool)
+
 func extractTags(mainStruct reflect.Value, tgName string, fieldIndex int) []tag {
+	typ := mainStruct.Type()
+	p := &plan{refs: map[string][]int{}}
+	for i := 0; i < typ.NumField(); i++ {
+		p.refs[typ.Field(i).Name] = []int{i}
+	}
+	vtag := typ.Field(fieldIndex).Tag.Get(tgName)
+	return resolveTags(parseTags(vtag), structRef(mainStruct, p))
+}
+
+// structRef returns a `refFunc` which resolves
+// field pointers to the fields of the given struct.
+func structRef(mainStruct reflect.Value, p *plan) refFunc {
+	return func(name string) (interface{}, bool) {
+		index, ok := p.refs[name]
+		if !ok {
+			return nil, false
+		}
+
+		// This used to check if the struct field pointer is pointing to itself
+		// I am not sure if we should allow this or not. If the user wants to
+		// he can point to himself I guess...
+		fv, ok := fieldByIndex(mainStruct, index)
+		if !ok {
+			return nil, true
+		}
+		in, _ := getInterface(fv)
+		return in, true
+	}
+}
+
+// parseTags parses a tag string in to a slice of tags,
+// field pointers are left as `fieldRef` arguments.
+func parseTags(vtag string) []tag {
 	tgs := make([]tag, 0)
-	vtag := mainStruct.Type().Field(fieldIndex).Tag.Get(tgName)
 	// Dont validate fields which have no tags
 	if vtag == "" || vtag == "-" {
 		return tgs
 	}
 
-	for _, t := range strings.Split(vtag, tagSep) {
-		parts := strings.Split(t, equalsSep)
+	tokens := strings.Split(vtag, tagSep)
+	for i := 0; i < len(tokens); i++ {
+		tg := parseTag(tokens[i])
 
-		tg := tag{
-			name: parts[0],
-			args: make([]interface{}, 0),
+		// `orSep` is split in to an empty token,
+		// which makes the next tag an alternative.
+		if i+2 < len(tokens) && tokens[i+1] == "" {
+			raw := []string{tokens[i]}
+			names := []string{tg.displayName()}
+			alts := [][]tag{{tg}}
+			for i+2 < len(tokens) && tokens[i+1] == "" {
+				alt := parseTag(tokens[i+2])
+				raw = append(raw, tokens[i+2])
+				names = append(names, alt.displayName())
+				alts = append(alts, []tag{alt})
+				i += 2
+			}
+
+			// Every alternative has the same severity and groups,
+			// which is checked by `checkSyntax`, so the first one is used.
+			tg = tag{
+				name:     strings.Join(names, orSep),
+				args:     make([]interface{}, 0),
+				severity: tg.severity,
+				groups:   tg.groups,
+				alts:     alts,
+			}
+			// `not(a||b)` is split in to `not(a` and `b)`
+			if first := strings.TrimPrefix(raw[0], warnPrefix); strings.HasPrefix(first, notPrefix) && !strings.HasSuffix(first, notSuffix) {
+				tg.err = fmt.Errorf("'%s' can't negate an OR group, negate every alternative instead", strings.Join(raw, orSep))
+			}
 		}
+		tgs = append(tgs, tg)
+	}
+
+	return tgs
+}
+
+// parseTag parses a single tag of a tag string.
+func parseTag(t string) tag {
+	severity := SeverityError
+	if strings.HasPrefix(t, warnPrefix) {
+		t = strings.TrimPrefix(t, warnPrefix)
+		severity = SeverityWarning
+	}
 
-		if !strings.Contains(t, equalsSep) || len(parts) == 1 {
-			tgs = append(tgs, tg)
+	negate := false
+	var err error
+	if strings.HasPrefix(t, notPrefix) && strings.HasSuffix(t, notSuffix) {
+		t = strings.TrimSuffix(strings.TrimPrefix(t, notPrefix), notSuffix)
+		negate = true
+	} else if strings.HasPrefix(t, notPrefix) {
+		err = fmt.Errorf("tag '%s' is missing the closing '%s'", t, notSuffix)
+	}
+
+	parts := strings.Split(t, equalsSep)
+
+	// Groups are a part of the tag name, so the
+	// arguments are free to contain the `groupSep` sign.
+	var groups []string
+	if i := strings.Index(parts[0], groupSep); i >= 0 {
+		groups = strings.Split(parts[0][i+1:], groupListSep)
+		parts[0] = parts[0][:i]
+	}
+
+	tg := tag{
+		name:     parts[0],
+		args:     make([]interface{}, 0),
+		severity: severity,
+		groups:   groups,
+		negate:   negate,
+		err:      err,
+	}
+
+	if !strings.Contains(t, equalsSep) || len(parts) == 1 {
+		return tg
+	}
+
+	for _, f := range strings.Split(parts[1], valueSep) {
+		// Defaults are converted to the type of their field when they're set,
+		// so they're kept as they're written - `1.0` and `007` are not numbers yet.
+		if tg.name == defaultTag && !strings.HasPrefix(f, pointerToField) {
+			tg.args = append(tg.args, f)
+			continue
+		}
+		if !strings.HasPrefix(f, pointerToField) {
+			tg.args = append(tg.args, parseArg(f))
 			continue
 		}
 
-		for _, f := range strings.Split(parts[1], valueSep) {
-			if !strings.HasPrefix(f, pointerToField) {
-				in, err := strconv.ParseInt(f, 10, 64)
-				if err == nil {
-					tg.args = append(tg.args, in)
-					continue
-				}
-				fl, err := strconv.ParseFloat(f, 64)
-				if err == nil {
-					tg.args = append(tg.args, fl)
-					continue
-				}
-
-				tg.args = append(tg.args, DerefInterface(f))
+		tg.args = append(tg.args, fieldRef(strings.TrimPrefix(f, pointerToField)))
+	}
+	return tg
+}
+
+// displayName returns the name of the tag used in errors.
+func (t tag) displayName() string {
+	if t.negate {
+		return notPrefix + t.name + notSuffix
+	}
+	return t.name
+}
+
+// resolveTags resolves the field pointers of the tags using the `ref` func,
+// pointers to fields that can't be found are dropped.
+// The given slice is never modified, a copy is returned if any pointers were found.
+func resolveTags(tgs []tag, ref refFunc) []tag {
+	var resolved []tag
+	for i, t := range tgs {
+		if !t.hasRefs() {
+			continue
+		}
+		if resolved == nil {
+			resolved = append(make([]tag, 0, len(tgs)), tgs...)
+		}
+
+		args := make([]interface{}, 0, len(t.args))
+		for _, arg := range t.args {
+			r, ok := arg.(fieldRef)
+			if !ok {
+				args = append(args, arg)
 				continue
 			}
+			if in, ok := ref(string(r)); ok {
+				args = append(args, in)
+			}
+		}
+		resolved[i].args = args
 
-			f = strings.TrimPrefix(f, pointerToField)
-			for j := 0; j < mainStruct.NumField(); j++ {
-				// This used to check if the struct field pointer is pointing to itself
-				// I am not sure if we should allow this or not. If the user wants to
-				// he can point to himself I guess...
-				// if mainStruct.Type().Field(currentField).Name == f {
-				// 	continue
-				// }
-
-				if mainStruct.Type().Field(j).Name == f {
-					in, _ := getInterface(mainStruct.Field(j))
-					tg.args = append(tg.args, in)
-				}
+		if len(t.alts) > 0 {
+			alts := make([][]tag, 0, len(t.alts))
+			for _, alt := range t.alts {
+				alts = append(alts, resolveTags(alt, ref))
 			}
+			resolved[i].alts = alts
 		}
-		tgs = append(tgs, tg)
 	}
 
-	return tgs
+	if resolved == nil {
+		return tgs
+	}
+	return resolved
+}
+
+func (t tag) hasRefs() bool {
+	for _, arg := range t.args {
+		if _, ok := arg.(fieldRef); ok {
+			return true
+		}
+	}
+	for _, alt := range t.alts {
+		for _, at := range alt {
+			if at.hasRefs() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isGroupName reports whether the string is a valid group name.
+func isGroupName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// parseArg converts a tag argument to an integer,
+// a floating point number or leaves it as a string.
+func parseArg(f string) interface{} {
+	in, err := strconv.ParseInt(f, 10, 64)
+	if err == nil {
+		return in
+	}
+	fl, err := strconv.ParseFloat(f, 64)
+	if err == nil {
+		return fl
+	}
+
+	return DerefInterface(f)
 }
 
 func validateTags(m map[string]struct{}) error {
@@ -90,7 +281,82 @@ func validateTags(m map[string]struct{}) error {
 func tagSliceToMap(tgsl []tag) map[string]struct{} {
 	m := map[string]struct{}{}
 	for _, f := range tgsl {
-		m[f.name] = struct{}{}
+		m[f.displayName()] = struct{}{}
 	}
 	return m
 }
+
+// ParsedTag is a single tag of a tag string, parsed the same way
+// `Validate` parses it. It can be used by tools which check
+// the tags without validating any structs.
+type ParsedTag struct {
+	Name string
+	// Args holds the arguments of the tag converted to int64,
+	// float64 or string, the field pointers are in `Refs`.
+	Args []interface{}
+	// Refs holds the names of the fields pointed to with the `*` sign.
+	Refs   []string
+	Negate bool
+	Warn   bool
+	Groups []string
+	// Alts holds the alternatives of an OR group.
+	Alts [][]ParsedTag
+}
+
+// ParseTags parses a tag string using the same grammar `Validate` does.
+// Example:
+/*
+
+	tags := vali.ParseTags("required|min=3|>|eq=a||eq=b")
+
+*/
+func ParseTags(s string) []ParsedTag {
+	return toParsedTags(parseTags(s))
+}
+
+// CheckSyntax returns the syntax error of a tag string the same way `Validate`
+// does, such as a `not(...)` wrapping an OR group or OR group alternatives
+// with different severities. Aliases are not expanded.
+func CheckSyntax(s string) error {
+	return checkSyntax(parseTags(s))
+}
+
+func toParsedTags(tgs []tag) []ParsedTag {
+	parsed := make([]ParsedTag, 0, len(tgs))
+	for _, t := range tgs {
+		pt := ParsedTag{
+			Name:   t.name,
+			Args:   make([]interface{}, 0, len(t.args)),
+			Refs:   make([]string, 0),
+			Negate: t.negate,
+			Warn:   t.severity == SeverityWarning,
+			Groups: t.groups,
+		}
+		for _, arg := range t.args {
+			if r, ok := arg.(fieldRef); ok {
+				pt.Refs = append(pt.Refs, string(r))
+				continue
+			}
+			pt.Args = append(pt.Args, arg)
+		}
+		for _, alt := range t.alts {
+			pt.Alts = append(pt.Alts, toParsedTags(alt))
+		}
+		parsed = append(parsed, pt)
+	}
+	return parsed
+}
+
+// HasTag reports whether the tag can be used in the tag strings,
+// the special tags and the registered aliases are included.
+func (v *Vali) HasTag(name string) bool {
+	switch name {
+	case dive, defaultTag, noFlattenTag:
+		return true
+	}
+	if _, ok := v.tags[name]; ok {
+		return true
+	}
+	_, ok := v.aliases[name]
+	return ok
+}
@@ -21,6 +21,16 @@ func Test_extractTags(t *testing.T) {
 	}{
 		First: 1,
 	}
+	mock4 := struct {
+		First int `json:"f" vali:"required|warn:max=5"`
+	}{
+		First: 1,
+	}
+	mock5 := struct {
+		First string `json:"f" vali:"required@create+update|one_of@create=a@b,c"`
+	}{
+		First: "a",
+	}
 
 	type args struct {
 		mainStruct reflect.Value
@@ -62,6 +72,28 @@ func Test_extractTags(t *testing.T) {
 				tag{name: minTag, args: []interface{}{int64(2), int64(3)}},
 			},
 		},
+		{
+			name: "should get two tags: required and max with a warning severity",
+			args: args{
+				mainStruct: reflect.ValueOf(mock4),
+				fieldIndex: 0,
+			},
+			want: []tag{
+				tag{name: requiredTag, args: []interface{}{}},
+				tag{name: maxTag, args: []interface{}{int64(5)}, severity: SeverityWarning},
+			},
+		},
+		{
+			name: "should get two tags with groups, arguments should keep the group sign",
+			args: args{
+				mainStruct: reflect.ValueOf(mock5),
+				fieldIndex: 0,
+			},
+			want: []tag{
+				tag{name: requiredTag, args: []interface{}{}, groups: []string{"create", "update"}},
+				tag{name: oneofTag, args: []interface{}{"a@b", "c"}, groups: []string{"create"}},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1,6 +1,8 @@
 package vali
 
 import (
+	"errors"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -1211,3 +1213,46 @@ func TestDups(t *testing.T) {
 		})
 	}
 }
+
+func TestNamedTypes(t *testing.T) {
+	type age int
+	type ratio float64
+	type size uint
+	type role string
+	type mock struct {
+		Age   age   `vali:"min=17|max=130"`
+		Ratio ratio `vali:"max=1.5"`
+		Size  size  `vali:"max=10"`
+		Role  role  `vali:"one_of=admin,user|neq=root"`
+	}
+
+	tests := []struct {
+		name string
+		s    *mock
+		want error
+	}{
+		{
+			name: "named types are valid, should compare them by their kind",
+			s:    &mock{Age: 18, Ratio: 1, Size: 10, Role: "user"},
+			want: nil,
+		},
+		{
+			name: "named types are invalid, should compare them by their kind",
+			s:    &mock{Age: 131, Ratio: 2, Size: 11, Role: "root"},
+			want: newAggErr().addErr(
+				newTagError("Age", maxTag, errors.New("131 is more than 130")),
+				newTagError("Ratio", maxTag, errors.New("2 is more than 1.5")),
+				newTagError("Size", maxTag, errors.New("11 is more than 10")),
+				newTagError("Role", oneofTag, errors.New("must have at least one of [admin user]")),
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := New().Validate(tt.s); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Vali.Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
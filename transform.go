@@ -0,0 +1,77 @@
+package vali
+
+// TransformFunc is a func that is used to transform a field `s`
+// using data providing in slice `o`, returning the new value of the field.
+// The returned value is set to the field, if the field can be set,
+// and the remaining tags of the field are validated against it.
+//
+// `s` is never nil, as there is nothing to transform.
+type TransformFunc func(s interface{}, o []interface{}) (interface{}, error)
+
+// transforms map stores the names of the tags
+// that transform the value of a field.
+type transforms map[string]struct{}
+
+// transformFunc adapts a `TransformFunc` to the `ResultFunc` signature.
+func transformFunc(fn TransformFunc) ResultFunc {
+	return func(s interface{}, o []interface{}) Result {
+		if s == nil {
+			return Result{}
+		}
+
+		val, err := fn(s, o)
+		if err != nil {
+			return Result{ValidationErr: err}
+		}
+		return Result{Replace: true, Value: val}
+	}
+}
+
+// SetTagTransform allows to create a new tag that transforms the field value,
+// the new value is set to the field in place and can be used anywhere in the tag chain.
+// Current tag that has the same name will get over written.
+// Example:
+/*
+
+	v.SetTagTransform("trim_prefix", func(s interface{}, o []interface{}) (interface{}, error) {
+		return strings.TrimPrefix(vali.GetString(s), vali.GetString(o[0])), nil
+	})
+
+*/
+func (v *Vali) SetTagTransform(tag string, fn TransformFunc) {
+	if fn == nil || tag == "" {
+		return
+	}
+
+	v.customize(tag)
+	v.tags[tag] = transformFunc(fn)
+	v.transforms[tag] = struct{}{}
+}
+
+// Sanitize only applies the transform tags of the struct fields,
+// setting the transformed values in place without validating them.
+// Example:
+/*
+
+	type User struct {
+		Name string `vali:"trim|collapse_ws|required|max=64"`
+	}
+
+	err := v.Sanitize(&user)
+
+*/
+func (v *Vali) Sanitize(s interface{}) error {
+	st := newState(ValidateOptions{})
+	st.sanitize = true
+	return v.validate(s, st)
+}
+
+// isTransform reports whether the tag transforms the value of a field.
+func (v *Vali) isTransform(t tag) bool {
+	if t.negate || len(t.alts) > 0 {
+		return false
+	}
+
+	_, ok := v.transforms[t.name]
+	return ok
+}
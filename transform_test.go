@@ -0,0 +1,79 @@
+package vali
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSetTagTransform(t *testing.T) {
+	type mock struct {
+		First string `vali:"strip=-|eq=abc"`
+	}
+
+	v := New()
+	v.SetTagTransform("", func(s interface{}, o []interface{}) (interface{}, error) {
+		return s, nil
+	})
+	v.SetTagTransform("nil", nil)
+	if _, ok := v.tags["nil"]; ok {
+		t.Error("expected not to find tag `nil`")
+	}
+
+	v.SetTagTransform("strip", func(s interface{}, o []interface{}) (interface{}, error) {
+		return strings.Replace(GetString(s), GetString(o[0]), "", -1), nil
+	})
+	if _, ok := v.transforms["strip"]; !ok {
+		t.Error("expected `strip` to be a transform")
+	}
+
+	s := &mock{First: "a-b-c"}
+	if err := v.Validate(s); err != nil {
+		t.Errorf("Vali.Validate() error = %v", err)
+	}
+	if s.First != "abc" {
+		t.Errorf("expected the field to be transformed, got %s", s.First)
+	}
+
+	v.SetTagValidation("strip", func(s interface{}, o []interface{}) error {
+		return nil
+	})
+	if _, ok := v.transforms["strip"]; ok {
+		t.Error("expected `strip` not to be a transform after it's overwritten")
+	}
+}
+
+func TestSanitize(t *testing.T) {
+	type Inner struct {
+		First string `vali:"required|upper"`
+	}
+	type mock struct {
+		First  string   `vali:"trim|required|max=2"`
+		Second []string `vali:"min=3|>|lower"`
+		In     *Inner   `vali:"required"`
+	}
+
+	v := New()
+	v.SetTypeValidation(&mock{}, func(s interface{}) error {
+		return errors.New("should not be called")
+	})
+
+	s := &mock{
+		First:  " abc ",
+		Second: []string{"A"},
+		In:     &Inner{First: "b"},
+	}
+	want := &mock{
+		First:  "abc",
+		Second: []string{"a"},
+		In:     &Inner{First: "B"},
+	}
+
+	if err := v.Sanitize(s); err != nil {
+		t.Errorf("Vali.Sanitize() error = %v", err)
+	}
+	if !reflect.DeepEqual(s, want) {
+		t.Errorf("Vali.Sanitize() = %+v, want %+v", s, want)
+	}
+}
@@ -0,0 +1,112 @@
+package vali
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+const (
+	// trimTag can be used to tag a struct field making
+	// it remove the leading and trailing white space of the field.
+	trimTag = "trim"
+	// lowerTag can be used to tag a struct field making
+	// it convert the field to lower case.
+	lowerTag = "lower"
+	// upperTag can be used to tag a struct field making
+	// it convert the field to upper case.
+	upperTag = "upper"
+	// titleTag can be used to tag a struct field making
+	// it convert the first letter of every word to upper case
+	// and the remaining letters to lower case.
+	titleTag = "title"
+	// collapseWSTag can be used to tag a struct field making
+	// it replace every sequence of white space with a single space
+	// and remove the leading and trailing white space.
+	collapseWSTag = "collapse_ws"
+	// truncateTag can be used to tag a struct field making
+	// it cut the field to be at most n characters long.
+	truncateTag = "truncate"
+)
+
+func trim(s interface{}, o []interface{}) (interface{}, error) {
+	str, err := transformString(s)
+	if err != nil {
+		return nil, err
+	}
+	return strings.TrimSpace(str), nil
+}
+
+func lower(s interface{}, o []interface{}) (interface{}, error) {
+	str, err := transformString(s)
+	if err != nil {
+		return nil, err
+	}
+	return strings.ToLower(str), nil
+}
+
+func upper(s interface{}, o []interface{}) (interface{}, error) {
+	str, err := transformString(s)
+	if err != nil {
+		return nil, err
+	}
+	return strings.ToUpper(str), nil
+}
+
+func title(s interface{}, o []interface{}) (interface{}, error) {
+	str, err := transformString(s)
+	if err != nil {
+		return nil, err
+	}
+
+	rs := []rune(str)
+	for i, r := range rs {
+		if i == 0 || unicode.IsSpace(rs[i-1]) {
+			rs[i] = unicode.ToUpper(r)
+			continue
+		}
+		rs[i] = unicode.ToLower(r)
+	}
+	return string(rs), nil
+}
+
+func collapseWS(s interface{}, o []interface{}) (interface{}, error) {
+	str, err := transformString(s)
+	if err != nil {
+		return nil, err
+	}
+	return strings.Join(strings.Fields(str), " "), nil
+}
+
+func truncate(s interface{}, o []interface{}) (interface{}, error) {
+	str, err := transformString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(o) == 0 {
+		return nil, errors.New("nothing to truncate to, [o] is empty")
+	}
+
+	n, ok := GetInt(o[0])
+	if !ok || n < 0 {
+		return nil, typeMismatch(s, o[0])
+	}
+
+	rs := []rune(str)
+	if int64(len(rs)) <= n {
+		return str, nil
+	}
+	return string(rs[:n]), nil
+}
+
+// transformString returns the string value of `s`
+// or an error if it's not a string.
+func transformString(s interface{}) (string, error) {
+	v := interfaceToReflectVal(s)
+	if v.Kind() != reflect.String {
+		return "", fmt.Errorf("can't transform a value of type %v, only strings are supported", reflect.TypeOf(s))
+	}
+	return v.String(), nil
+}
@@ -0,0 +1,83 @@
+package vali
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTransformTags(t *testing.T) {
+	type mock struct {
+		First string `vali:"trim|collapse_ws|lower|one_of=a b,c"`
+	}
+	type mock2 struct {
+		First  *string  `vali:"optional|upper|title"`
+		Second []string `vali:">|trim|upper"`
+	}
+	type mock3 struct {
+		First string `vali:"truncate=3"`
+	}
+	type mock4 struct {
+		First int `vali:"trim"`
+	}
+	type myStr string
+	type mock5 struct {
+		First myStr `vali:"upper"`
+	}
+
+	hello := "hELLO wORLD"
+	tests := []struct {
+		name    string
+		s       interface{}
+		want    interface{}
+		wantErr bool
+	}{
+		{
+			name: "string is trimmed, collapsed and lowered, should be valid",
+			s:    &mock{First: "  A \t  B "},
+			want: &mock{First: "a b"},
+		},
+		{
+			name: "string pointer is set to title case",
+			s:    &mock2{First: &hello},
+			want: &mock2{First: func() *string { s := "Hello World"; return &s }()},
+		},
+		{
+			name: "slice elements are trimmed and set to upper case",
+			s:    &mock2{Second: []string{" a ", "b "}},
+			want: &mock2{Second: []string{"A", "B"}},
+		},
+		{
+			name: "string is truncated",
+			s:    &mock3{First: "ąbcd"},
+			want: &mock3{First: "ąbc"},
+		},
+		{
+			name: "short string is not truncated",
+			s:    &mock3{First: "ab"},
+			want: &mock3{First: "ab"},
+		},
+		{
+			name: "named string type is converted back",
+			s:    &mock5{First: "a"},
+			want: &mock5{First: "A"},
+		},
+		{
+			name:    "int can't be trimmed, should error",
+			s:       &mock4{First: 1},
+			want:    &mock4{First: 1},
+			wantErr: true,
+		},
+	}
+
+	v := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := v.Validate(tt.s); (err != nil) != tt.wantErr {
+				t.Errorf("Vali.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(tt.s, tt.want) {
+				t.Errorf("Vali.Validate() = %+v, want %+v", tt.s, tt.want)
+			}
+		})
+	}
+}
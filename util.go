@@ -107,6 +107,8 @@ func getInterface(v reflect.Value) (interface{}, bool) {
 		}
 
 		return getInterface(v.Elem())
+	case reflect.Invalid:
+		return nil, false
 	default:
 		return v.Interface(), true
 	}
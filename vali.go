@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sync"
+	"sync/atomic"
 )
 
 const (
@@ -36,20 +38,91 @@ const (
 	 }
 	*/
 	dive = ">"
+	// defaultTag sets the given value to the field if it's zero valued,
+	// before any other tag of the field is validated.
+	// Slices get an element for every value, nil pointers are allocated.
+	// Example:
+	/*
+	 type mock struct {
+	 Port int `vali:"default=8080|max=65535"`
+	 }
+	*/
+	defaultTag = "default"
+	// noFlattenTag keeps the fields of an embedded struct from being promoted,
+	// the struct is validated as a nested field named after its type.
+	// Example:
+	/*
+	 type mock struct {
+	 Base `vali:"no_flatten"`
+	 }
+	*/
+	noFlattenTag = "no_flatten"
+	// warnPrefix can be added to any tag to make its
+	// failure a warning instead of an error.
+	// Example:
+	/*
+	 type mock struct {
+	 Str string `vali:"required|warn:max=64"`
+	 }
+	*/
+	warnPrefix = "warn:"
+	// groupSep is used to add validation groups to a tag name.
+	// Example:
+	/*
+	 type mock struct {
+	 Str string `vali:"required@create|optional@update|max@create=64"`
+	 }
+	*/
+	groupSep = "@"
+	// groupListSep is used to seperate the groups of a single tag.
+	groupListSep = "+"
+	// orSep is used to seperate the alternatives of an OR group,
+	// the group passes if any of the alternatives pass.
+	// Every alternative has to have the same severity and groups.
+	// Example:
+	/*
+	 type mock struct {
+	 Str string `vali:"required|ipv4||hostname"`
+	 }
+	*/
+	orSep = "||"
+	// notPrefix and notSuffix wrap a tag inverting its outcome,
+	// they can't wrap an OR group - `not(a||b)` is an error.
+	// Example:
+	/*
+	 type mock struct {
+	 Str string `vali:"required|not(one_of=admin,root)"`
+	 }
+	*/
+	notPrefix = "not("
+	notSuffix = ")"
+	// pathSep is used to seperate the field names of
+	// nested struct fields in a field path.
+	pathSep = "."
 )
 
 // tags if a type of map which holds all tag validation funcs
-type tags map[string]TagFunc
+type tags map[string]ResultFunc
 
 // types map stores all the types that we can validate.
 // These types can be set by the package user.
 type types map[reflect.Type]TypeFunc
 
+// severities map stores the severity set for a tag.
+type severities map[string]Severity
+
+// groups map stores the registered validation groups.
+type groups map[string]struct{}
+
+// aliases map stores the tag strings of the registered aliases.
+type aliases map[string]string
+
 // TagFunc is a func that is used to validate a field `s`
 // using data providing in slice `o`.
 //
 // `s` can be nil if `Config.IgnoreNilPointer` is set to true
 // Slice `o` can be empty or nil and doesn't have to be used if not needed.
+// A TagFunc is adapted to a `ResultFunc` when it's registered.
 type TagFunc func(s interface{}, o []interface{}) error
 
 // Type func is a func that is can be set and used
@@ -65,9 +138,21 @@ type TypeFunc func(s interface{}) error
 // as no thread safety exists, so using and editting validation funcs
 // will result in a race condition.
 type Vali struct {
-	tags   tags
-	types  types
-	tgName string
+	tags       tags
+	types      types
+	severities severities
+	groups     groups
+	aliases    aliases
+	transforms transforms
+	schemas    schemas
+	rules      rules
+	plans      atomic.Value
+	loadMu     sync.Mutex
+	typeNames  typeNames
+	tgName     string
+	// customized is set when any of the tags
+	// valigen turns in to code are replaced.
+	customized bool
 }
 
 // ErrSkipFurther is an error that can be used as a return value
@@ -78,38 +163,180 @@ var ErrSkipFurther = errors.New("skip further")
 // New returns a new validator instance,
 // with the default predefined types.
 func New() *Vali {
-	return &Vali{
-		tgName: valiTag,
-		types:  map[reflect.Type]TypeFunc{},
-		tags: map[string]TagFunc{
-			requiredTag:        required,
-			requiredWithoutTag: required_without,
-			maxTag:             max,
-			minTag:             min,
-			oneofTag:           oneof,
-			noneofTag:          noneof,
-			eqTag:              eq,
-			neqTag:             neq,
-			dupsTag:            dups,
-			optionalTag:        optional,
+	v := &Vali{
+		tgName:     valiTag,
+		types:      map[reflect.Type]TypeFunc{},
+		severities: map[string]Severity{},
+		groups:     map[string]struct{}{},
+		aliases:    map[string]string{},
+		rules:      map[reflect.Type]map[string][]tag{},
+		typeNames:  map[string]reflect.Type{},
+		tags: map[string]ResultFunc{
+			requiredTag:        resultFunc(required),
+			requiredWithoutTag: resultFunc(required_without),
+			maxTag:             resultFunc(max),
+			minTag:             resultFunc(min),
+			oneofTag:           resultFunc(oneof),
+			noneofTag:          resultFunc(noneof),
+			eqTag:              resultFunc(eq),
+			neqTag:             resultFunc(neq),
+			dupsTag:            resultFunc(dups),
+			optionalTag:        resultFunc(optional),
+			trimTag:            transformFunc(trim),
+			lowerTag:           transformFunc(lower),
+			upperTag:           transformFunc(upper),
+			titleTag:           transformFunc(title),
+			collapseWSTag:      transformFunc(collapseWS),
+			truncateTag:        transformFunc(truncate),
+		},
+		transforms: map[string]struct{}{
+			trimTag:       {},
+			lowerTag:      {},
+			upperTag:      {},
+			titleTag:      {},
+			collapseWSTag: {},
+			truncateTag:   {},
+		},
+		schemas: map[string]SchemaFunc{
+			maxTag:    maxSchema,
+			minTag:    minSchema,
+			oneofTag:  oneofSchema,
+			noneofTag: noneofSchema,
+			eqTag:     eqSchema,
+			neqTag:    neqSchema,
+			dupsTag:   dupsSchema,
 		},
 	}
+	v.storePlans(nil)
+	return v
 }
 
 // NewEmpty returns a new vali validator without
 // any predefined tags allowing the user to configure whatever he needs.
 func NewEmpty() *Vali {
-	return &Vali{
-		tgName: valiTag,
-		types:  map[reflect.Type]TypeFunc{},
-		tags:   map[string]TagFunc{},
+	v := &Vali{
+		tgName:     valiTag,
+		types:      map[reflect.Type]TypeFunc{},
+		severities: map[string]Severity{},
+		groups:     map[string]struct{}{},
+		aliases:    map[string]string{},
+		rules:      map[reflect.Type]map[string][]tag{},
+		typeNames:  map[string]reflect.Type{},
+		tags:       map[string]ResultFunc{},
+		transforms: map[string]struct{}{},
+		schemas:    map[string]SchemaFunc{},
 	}
+	v.storePlans(nil)
+	return v
+}
+
+// ValidateOptions can be used to change how the `ValidateWithOptions`
+// method walks the given struct and collects errors.
+type ValidateOptions struct {
+	// StopOnFirst stops the validation after the first error is found,
+	// this is useful when you only care if the struct is valid or not.
+	StopOnFirst bool
+	// MaxErrors limits the amount of errors that are collected
+	// before the validation is stopped. Zero or less means no limit.
+	MaxErrors int
+	// MaxDepth limits how deep nested structs are validated, one only validates
+	// the fields of the struct itself. Nested structs past the limit are not validated
+	// and return a `FieldError` with the `depth` tag and `ErrMaxDepth`, structs
+	// which have nothing to validate, such as `time.Time`, are left alone.
+	// Zero or less means no limit.
+	MaxDepth int
+}
+
+// state holds the data of a single validation run,
+// it's shared between nested struct validations.
+type state struct {
+	opts ValidateOptions
+	// errCount is the amount of field errors found so far.
+	errCount int
+	// bubbled is set when a bubbled error has to abort the whole validation.
+	bubbled bool
+	// warnings holds the field warnings returned by the tag funcs.
+	warnings []error
+	// filter limits the validated fields, nil means all fields are validated.
+	filter *filter
+	// path is the field path of the struct that is being validated.
+	path string
+	// errPath is the path of the struct that is being validated used in the errors,
+	// unlike `path` it's always made of the names of the fields.
+	errPath string
+	// groups holds the selected validation groups.
+	groups map[string]struct{}
+	// sanitize is set when only the transform tags have to be applied.
+	sanitize bool
+	// plans are the plans used during the validation.
+	plans *plans
+	// visits holds the structs that are being validated, a struct
+	// which is reached again through a cycle of pointers is skipped.
+	visits visits
+	// depth is the amount of nested structs descended in to.
+	depth int
+	// reflect is set when the generated methods must not be used.
+	reflect bool
+}
+
+func newState(opts ValidateOptions) *state {
+	return &state{opts: opts, visits: visits{}}
+}
+
+// addErr adds the error to the aggregation and counts it.
+func (st *state) addErr(errs *AggErr, err error) {
+	errs.addErr(err)
+	st.errCount++
+}
+
+// validates reports whether the tags of the field
+// at the given path have to be validated.
+func (st *state) validates(path string) bool {
+	validate, _ := st.filter.match(path)
+	return validate
+}
+
+// fieldPath returns the path of the given field in the struct
+// that is being validated, it reports false if the field can't be matched.
+func (st *state) fieldPath(f reflect.StructField) (string, bool) {
+	name, ok := st.filter.fieldName(f)
+	if st.path == "" {
+		return name, ok
+	}
+	return st.path + pathSep + name, ok
+}
+
+// joinPath adds the name to the path of a struct.
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + pathSep + name
+}
+
+// done reports whether enough errors were collected
+// and the validation should be stopped.
+func (st *state) done() bool {
+	limit := st.opts.MaxErrors
+	if st.opts.StopOnFirst {
+		limit = 1
+	}
+
+	return limit > 0 && st.errCount >= limit
 }
 
 // Validate accepts a struct and validates its according to the given tags.
 // Validations are applied in this order:
 // 1. Type validation if one is set.
-// 2. Tag validation in order the tags were set.
+// 2. Default values of zero valued fields are set.
+// 3. Tag validation in order the tags were set.
+//
+// Nested structs held by pointers or interfaces are validated by their dynamic type,
+// nil pointers and interfaces are not descended in to. Structs held by value in an
+// interface are validated as a copy, so the values set by their tags are not kept.
+// The fields of embedded structs are validated as fields of the struct itself,
+// unless the embedded struct has the `no_flatten` tag. Structs reached again
+// through a cycle of pointers are skipped as they're already being validated.
 //
 // The return value `error` can be type asserted in to `*vali.AggErr`
 // which allows to explore each error seprately.
@@ -122,6 +349,23 @@ func NewEmpty() *Vali {
 
 */
 func (v *Vali) Validate(s interface{}) error {
+	return v.validate(s, newState(ValidateOptions{}))
+}
+
+// ValidateWithOptions works the same as `Validate` but allows
+// to stop the validation early using the given options.
+// The limits are shared between the struct and all of its nested structs.
+// Example:
+/*
+
+	err := vali.New().ValidateWithOptions(str, vali.ValidateOptions{StopOnFirst: true})
+
+*/
+func (v *Vali) ValidateWithOptions(s interface{}, opts ValidateOptions) error {
+	return v.validate(s, newState(opts))
+}
+
+func (v *Vali) validate(s interface{}, st *state) error {
 	errs := newAggErr()
 
 	if s == nil {
@@ -133,7 +377,6 @@ func (v *Vali) Validate(s interface{}) error {
 		return errs.addErr(fmt.Errorf("function only accepts pointer to structs; got %s", val.Kind()))
 	}
 
-	orgVal := val
 	for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
 		if val.IsNil() {
 			break
@@ -146,60 +389,171 @@ func (v *Vali) Validate(s interface{}) error {
 		return errs.addErr(fmt.Errorf("function only accepts structs; got %s", val.Kind()))
 	}
 
-	if fn, ok := v.types[val.Type()]; ok {
-		if err := fn(orgVal.Interface()); err != nil {
-			errs.addErr(err)
-		}
+	// Structs held by value in an interface are validated as a copy
+	ptr := structPtr(val)
+	val = reflect.ValueOf(ptr).Elem()
+
+	// The struct is already being validated, it was reached through a cycle
+	if !st.visits.enter(val) {
+		return nil
 	}
+	defer st.visits.leave(val)
 
-	for i := 0; i < val.NumField(); i++ {
-		if !val.Field(i).CanSet() {
+	if st.plans == nil {
+		st.plans = v.loadPlans()
+	}
+	p := v.plan(st.plans, val.Type())
+
+	if fn, ok := v.types[val.Type()]; ok && !st.sanitize && st.validates(st.path) {
+		if err := fn(ptr); err != nil {
+			st.addErr(errs, err)
+			if st.done() {
+				return errs.toError()
+			}
+		}
+	}
+	// Flattened embedded structs are not descended in to, so their type validations are run here
+	for _, index := range p.embeds {
+		ev, ok := fieldByIndex(val, index)
+		if !ok {
 			continue
 		}
-
-		tags := extractTags(val, v.tgName, i)
-		if len(tags) == 0 {
+		ev, ok = derefReflectValue(ev)
+		if !ok {
 			continue
 		}
+		if fn, ok := v.types[ev.Type()]; ok && !st.sanitize && st.validates(st.path) {
+			if err := fn(structPtr(ev)); err != nil {
+				st.addErr(errs, err)
+				if st.done() {
+					return errs.toError()
+				}
+			}
+		}
+	}
 
-		m := tagSliceToMap(tags)
-		if err := validateTags(m); err != nil {
-			errs.addErr(err)
-			continue
+	if gen, ok := v.genMethods(val, st); ok {
+		g := v.genState(val, st)
+		g.typed = true
+		g.errs = errs
+		err := gen.ValidateValiWith(g)
+		if err != nil && g.err != nil {
+			st.bubbled = true
+		}
+		return err
+	}
+
+	ref := structRef(val, p)
+	for _, fp := range p.fields {
+		done, err := v.validatePlanField(val, ref, fp, st, errs)
+		if err != nil {
+			return err
+		}
+		if done {
+			break
+		}
+	}
+
+	return errs.toError()
+}
+
+// validatePlanField validates a single field of the struct using its plan,
+// adding the field errors to `errs`. It reports whether enough errors were
+// collected, while the returned error aborts the whole validation.
+func (v *Vali) validatePlanField(val reflect.Value, ref refFunc, fp fieldPlan, st *state, errs *AggErr) (bool, error) {
+	fv, ok := fieldByIndex(val, fp.index)
+	if !ok || !fv.CanSet() {
+		return false, nil
+	}
+
+	path, ok := st.fieldPath(fp.field)
+	if !ok {
+		return false, nil
+	}
+	errPath := joinPath(st.errPath, fp.field.Name)
+	validate, descend := st.filter.match(path)
+	if !validate && !descend {
+		return false, nil
+	}
+
+	tags, err := fp.tags, fp.err
+	if err == nil && fp.grouped {
+		tags = activeTags(tags, st.groups)
+		if len(tags) == 0 {
+			return false, nil
 		}
+		err = validateTags(tagSliceToMap(tags))
+	}
+	if err != nil {
+		st.addErr(errs, err)
+		return st.done(), nil
+	}
 
-		// This is sort of hacky way to allow us to easily
-		// convert between a "dive" validation and a single field
-		// validation.
-		// Maybe it should be improved in the future
-		cmp := []interface{}{
-			DerefInterface(val.Field(i).Interface()),
+	if validate {
+		tags = resolveTags(tags, ref)
+	}
+	if validate && !st.sanitize {
+		if err := applyDefault(fp.field.Name, errPath, fv, tags); err != nil {
+			st.addErr(errs, err)
+			return st.done(), nil
 		}
+	}
+
+	// This is sort of hacky way to allow us to easily
+	// convert between a "dive" validation and a single field
+	// validation.
+	// Maybe it should be improved in the future
+	cmp := []interface{}{
+		DerefInterface(fv.Interface()),
+	}
 
-		if derf, ok := derefReflectValue(val.Field(i)); ok {
-			if derf.Kind() == reflect.Struct {
-				ss := val.Field(i).Interface()
-				if ers := v.Validate(&ss); ers != nil {
-					errs.addErr(ers)
+	if derf, ok := derefReflectValue(fv); ok && descend && !fp.flat && derf.Kind() == reflect.Struct {
+		if st.opts.MaxDepth > 0 && st.depth+1 >= st.opts.MaxDepth && v.hasValidations(st.plans, derf.Type()) {
+			// The tags of the field are still validated if it's too deep to descend in to
+			st.addErr(errs, &FieldError{Field: fp.field.Name, Path: errPath, Tag: depthTag, Err: ErrMaxDepth})
+		} else {
+			parent, errParent := st.path, st.errPath
+			st.path, st.errPath = path, errPath
+			st.depth++
+			ers := v.validate(structPtr(derf), st)
+			st.depth--
+			st.path, st.errPath = parent, errParent
+			if ers != nil {
+				if st.bubbled {
+					return false, ers
 				}
+				if fv.Kind() == reflect.Interface {
+					withStructType(ers, fv.Elem().Type())
+				}
+				errs.addErr(ers)
 			}
 		}
+		if st.done() {
+			return true, nil
+		}
+	}
 
-		if err := v.validateField(val.Type().Field(i).Name, cmp, tags); err != nil {
-			var b *bubbleErr
-			var e *tagError
+	if !validate {
+		return false, nil
+	}
 
-			if errors.As(err, &b) {
-				return b.err
-			} else if errors.As(err, &e) {
-				errs.addErr(e)
-			} else {
-				return err
-			}
+	dst := []reflect.Value{settableValue(fv)}
+	if err := v.validateField(fp.field.Name, errPath, cmp, dst, tags, st); err != nil {
+		var b *bubbleErr
+		var e *FieldError
+
+		if errors.As(err, &b) {
+			st.bubbled = true
+			return false, b.err
+		} else if errors.As(err, &e) {
+			st.addErr(errs, e)
+			return st.done(), nil
+		} else {
+			return false, err
 		}
 	}
 
-	return errs.toError()
+	return false, nil
 }
 
 // SetTagValidation allows to create a new tag and use it for validation.
@@ -218,12 +572,36 @@ func (v *Vali) SetTagValidation(tag string, fn TagFunc) {
 		return
 	}
 
+	v.customize(tag)
+	v.tags[tag] = resultFunc(fn)
+	delete(v.transforms, tag)
+}
+
+// SetTagResultValidation works the same as `SetTagValidation`
+// but accepts a `ResultFunc`, which can skip the remaining tags,
+// abort the validation, return a warning or replace the validated value.
+// Example:
+/*
+
+	v.SetTagResultValidation("trim", func(s interface{}, o []interface{}) vali.Result {
+		return vali.Result{Replace: true, Value: strings.TrimSpace(vali.GetString(s))}
+	})
+
+*/
+func (v *Vali) SetTagResultValidation(tag string, fn ResultFunc) {
+	if fn == nil || tag == "" {
+		return
+	}
+
+	v.customize(tag)
 	v.tags[tag] = fn
+	delete(v.transforms, tag)
 }
 
 // SetTypeValidation allows to create new validation funcs for types.
 // *T will get rendered down to T, so *T and T will have the same
-// validation type func set.
+// validation type func set. The func always receives a *T, including
+// for the fields which hold a T in an interface.
 // Setting validation func will override any previous type validation funcs.
 // You can return custom errors from custom tags by returning a BubbleErr.
 // Example:
@@ -263,38 +641,58 @@ func (v *Vali) RenameTag(t string) {
 	}
 
 	v.tgName = t
+	v.resetPlans()
 }
 
 // validateField is a helper method which holds the validation code for a specific
 // field. It calls itself recursively if it finds a dive tag validating
 // the inside of a given `slice` or `array`.
-func (v *Vali) validateField(field string, cmp []interface{}, tags []tag) error {
-	for _, c := range cmp {
+// Values replaced by the tags are set to `dst`, if the values can be set.
+func (v *Vali) validateField(field, path string, cmp []interface{}, dst []reflect.Value, tags []tag, st *state) error {
+	for j, c := range cmp {
 		for i, t := range tags {
 			if t.name == dive {
-				cmp, err := rebuildCmpSlice(cmp[0])
+				cmp, dst, err := rebuildCmpSlice(c, dst[j])
 				if err != nil {
-					return newTagError(field, t.name, err)
+					return t.fieldError(field, path, err)
 				}
-				return v.validateField(field, cmp, tags[i+1:])
+				for k := range cmp {
+					elemPath := fmt.Sprintf("%s[%d]", path, k)
+					if err := v.validateField(field, elemPath, cmp[k:k+1], dst[k:k+1], tags[i+1:], st); err != nil {
+						return err
+					}
+				}
+				break
 			}
-			fn, ok := v.tags[t.name]
+			if st.sanitize && !v.isTransform(t) {
+				continue
+			}
+
+			res, ok := v.runTag(t, c)
 			if !ok {
 				// no such tag
 				// TODO consider throwing an error here
 				continue
 			}
 
-			if err := fn(c, t.args); err != nil {
-				if errors.Is(err, ErrSkipFurther) {
-					return nil
-				}
-
-				var b *bubbleErr
-				if errors.As(err, &b) {
-					return b
+			switch {
+			case res.BubbleErr != nil:
+				return BubbleErr(res.BubbleErr)
+			case res.ValidationErr != nil && v.severity(t) == SeverityWarning:
+				st.warnings = append(st.warnings, t.fieldError(field, path, res.ValidationErr))
+			case res.ValidationErr != nil:
+				return t.fieldError(field, path, res.ValidationErr)
+			case res.Warning != nil:
+				st.warnings = append(st.warnings, t.fieldError(field, path, res.Warning))
+			}
+			if res.Skip {
+				break
+			}
+			if res.Replace {
+				c = res.Value
+				if err := setReplaced(dst[j], c); err != nil {
+					return t.fieldError(field, path, err)
 				}
-				return newTagError(field, t.name, err)
 			}
 		}
 	}
@@ -302,20 +700,67 @@ func (v *Vali) validateField(field string, cmp []interface{}, tags []tag) error
 }
 
 // rebuildCmpSlice is a helper function to rebuild the comparison
-// slice if possible
-func rebuildCmpSlice(val interface{}) ([]interface{}, error) {
+// slice if possible. The elements are taken from `dst` if it can be set,
+// as it holds the replaced values, so the elements can be set as well.
+func rebuildCmpSlice(val interface{}, dst reflect.Value) ([]interface{}, []reflect.Value, error) {
 	derf := interfaceToReflectVal(val)
+	if dst.IsValid() && dst.CanSet() {
+		derf = dst
+	}
 	switch derf.Kind() {
 	case reflect.Array, reflect.Slice:
 	default:
-		return nil, errors.New("value is not a slice, can't use it")
+		return nil, nil, errors.New("value is not a slice, can't use it")
 	}
 
 	newcmp := []interface{}{}
+	newdst := []reflect.Value{}
 	for j := 0; j < derf.Len(); j++ {
 		newcmp = append(
 			newcmp,
 			DerefInterface(derf.Index(j).Interface()))
+		newdst = append(newdst, settableValue(derf.Index(j)))
+	}
+	return newcmp, newdst, nil
+}
+
+// structPtr returns a pointer to the struct held by a field, resolved through
+// its pointers and interfaces. Structs that can't be addressed, such as the ones
+// held by value in an interface, are copied, so the changes made by the tags are not kept.
+func structPtr(v reflect.Value) interface{} {
+	if v.CanAddr() {
+		return v.Addr().Interface()
+	}
+
+	ptr := reflect.New(v.Type())
+	ptr.Elem().Set(v)
+	return ptr.Interface()
+}
+
+// settableValue returns the value that replaced values of `v`
+// have to be set to, pointers are dereferenced.
+func settableValue(v reflect.Value) reflect.Value {
+	if v.Kind() == reflect.Interface {
+		return reflect.Value{}
+	}
+	derf, _ := derefReflectValue(v)
+	return derf
+}
+
+// setReplaced sets the value replaced by a tag to `dst` if it can be set.
+func setReplaced(dst reflect.Value, val interface{}) error {
+	if !dst.IsValid() || !dst.CanSet() || val == nil {
+		return nil
 	}
-	return newcmp, nil
+
+	nv := reflect.ValueOf(val)
+	switch {
+	case nv.Type().AssignableTo(dst.Type()):
+		dst.Set(nv)
+	case nv.Kind() == dst.Kind() && nv.Type().ConvertibleTo(dst.Type()):
+		dst.Set(nv.Convert(dst.Type()))
+	default:
+		return fmt.Errorf("value of type %s can't be set to a field of type %s", nv.Type(), dst.Type())
+	}
+	return nil
 }
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -73,7 +74,7 @@ func TestValidate(t *testing.T) {
 					First: []string{"c", "c", "c"},
 				},
 			},
-			want: newAggErr().addErr(newTagError("First", oneofTag, errors.New("must have at least one of [a b]"))),
+			want: newAggErr().addErr(withPath(newTagError("First", oneofTag, errors.New("must have at least one of [a b]")), "First[0]")),
 		},
 		{
 			name: "struct has a slice prefixed with dive (>), length is less than required, should error",
@@ -94,7 +95,7 @@ func TestValidate(t *testing.T) {
 					},
 				},
 			},
-			want: newAggErr().addErr(newAggErr().addErr(newTagError("First", eqTag, errors.New("b is not equal to a")))),
+			want: newAggErr().addErr(newAggErr().addErr(withPath(newTagError("First", eqTag, errors.New("b is not equal to a")), "M.First"))),
 		},
 		{
 			name: "struct is nil, should error",
@@ -428,3 +429,520 @@ func TestValidateBubbleErr(t *testing.T) {
 		}
 	})
 }
+
+func TestValidateWithOptions(t *testing.T) {
+	type Inner struct {
+		First  string `vali:"eq=a"`
+		Second int    `vali:"max=5"`
+	}
+	type mock struct {
+		First  string   `vali:"required"`
+		Second int      `vali:"min=2"`
+		Third  []string `vali:">|one_of=a,b"`
+		In     *Inner   `vali:"required"`
+	}
+	s := &mock{
+		Third: []string{"a", "c"},
+		In: &Inner{
+			First:  "b",
+			Second: 6,
+		},
+	}
+
+	tests := []struct {
+		name string
+		opts ValidateOptions
+		want int
+	}{
+		{
+			name: "no options, should collect all errors",
+			opts: ValidateOptions{},
+			want: 5,
+		},
+		{
+			name: "stop on first, should collect a single error",
+			opts: ValidateOptions{StopOnFirst: true},
+			want: 1,
+		},
+		{
+			name: "max errors is 3, should stop after the dive validation",
+			opts: ValidateOptions{MaxErrors: 3},
+			want: 3,
+		},
+		{
+			name: "max errors is 4, should stop inside of the nested struct",
+			opts: ValidateOptions{MaxErrors: 4},
+			want: 4,
+		},
+		{
+			name: "max errors is more than errors found, should collect all errors",
+			opts: ValidateOptions{MaxErrors: 10},
+			want: 5,
+		},
+	}
+
+	v := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.ValidateWithOptions(s, tt.opts)
+			var agg *AggErr
+			if !errors.As(err, &agg) {
+				t.Fatalf("Vali.ValidateWithOptions() = %v, want *AggErr", err)
+			}
+			if got := countErrs(agg); got != tt.want {
+				t.Errorf("Vali.ValidateWithOptions() found %d errors, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func countErrs(agg *AggErr) int {
+	count := 0
+	for _, err := range agg.Sl {
+		var nested *AggErr
+		if errors.As(err, &nested) {
+			count += countErrs(nested)
+			continue
+		}
+		count++
+	}
+	return count
+}
+
+type benchInner struct {
+	First  string `vali:"eq=a"`
+	Second int    `vali:"min=2|max=5"`
+}
+
+type benchMock struct {
+	First  string      `vali:"required|one_of=a,b,c"`
+	Second int         `vali:"min=2|max=10"`
+	Third  []string    `vali:"min=1|>|one_of=a,b"`
+	Fourth *int        `vali:"optional|max=2"`
+	Fifth  string      `vali:"neq=*First"`
+	In     *benchInner `vali:"required"`
+}
+
+func newBenchMock() *benchMock {
+	return &benchMock{
+		First:  "d",
+		Second: 11,
+		Third:  []string{"a", "c"},
+		Fifth:  "d",
+		In: &benchInner{
+			First:  "b",
+			Second: 7,
+		},
+	}
+}
+
+func BenchmarkValidate(b *testing.B) {
+	v := New()
+	s := newBenchMock()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = v.Validate(s)
+	}
+}
+
+func BenchmarkValidateStopOnFirst(b *testing.B) {
+	v := New()
+	s := newBenchMock()
+	opts := ValidateOptions{StopOnFirst: true}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = v.ValidateWithOptions(s, opts)
+	}
+}
+
+func BenchmarkValidateMaxErrors(b *testing.B) {
+	v := New()
+	s := newBenchMock()
+	opts := ValidateOptions{MaxErrors: 2}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = v.ValidateWithOptions(s, opts)
+	}
+}
+
+func TestSetTagResultValidation(t *testing.T) {
+	errAbort := errors.New("abort")
+	type mock struct {
+		First string `vali:"skip|eq=b"`
+	}
+	type mock2 struct {
+		First string `vali:"upper|eq=A"`
+	}
+	type mock3 struct {
+		First  string `vali:"abort"`
+		Second string `vali:"eq=b"`
+	}
+	type mock4 struct {
+		First string `vali:"warn|eq=a"`
+	}
+	type mock5 struct {
+		First []string `vali:">|upper|eq=A"`
+	}
+
+	v := New()
+	v.SetTagResultValidation("", func(s interface{}, o []interface{}) Result {
+		return Result{}
+	})
+	v.SetTagResultValidation("nil", nil)
+	if _, ok := v.tags["nil"]; ok {
+		t.Error("expected not to find tag `nil`")
+	}
+
+	v.SetTagResultValidation("skip", func(s interface{}, o []interface{}) Result {
+		return Result{Skip: true}
+	})
+	v.SetTagResultValidation("upper", func(s interface{}, o []interface{}) Result {
+		return Result{Replace: true, Value: strings.ToUpper(GetString(s))}
+	})
+	v.SetTagResultValidation("abort", func(s interface{}, o []interface{}) Result {
+		return Result{BubbleErr: errAbort}
+	})
+	v.SetTagResultValidation("warn", func(s interface{}, o []interface{}) Result {
+		return Result{Warning: errors.New("deprecated")}
+	})
+
+	tests := []struct {
+		name string
+		s    interface{}
+		want error
+	}{
+		{
+			name: "skip result, remaining tags should be skipped",
+			s:    &mock{First: "a"},
+			want: nil,
+		},
+		{
+			name: "replace result, remaining tags should get the new value",
+			s:    &mock2{First: "a"},
+			want: nil,
+		},
+		{
+			name: "replace result inside of a dive, remaining tags should get the new value",
+			s:    &mock5{First: []string{"a", "a"}},
+			want: nil,
+		},
+		{
+			name: "bubble result, should abort and return the error as is",
+			s:    &mock3{First: "a"},
+			want: errAbort,
+		},
+		{
+			name: "warning result, should not make the field invalid",
+			s:    &mock4{First: "a"},
+			want: nil,
+		},
+		{
+			name: "warning result, remaining tags should still be validated",
+			s:    &mock4{First: "b"},
+			want: newAggErr().addErr(newTagError("First", eqTag, errors.New("b is not equal to a"))),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := v.Validate(tt.s); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Vali.Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// withPath sets the path of a nested field error.
+func withPath(err error, path string) error {
+	err.(*FieldError).Path = path
+	return err
+}
+
+func withType(err error, typ interface{}) error {
+	err.(*FieldError).Type = reflect.TypeOf(typ)
+	return err
+}
+
+type ifaceShape interface {
+	Area() int
+}
+
+type ifaceSquare struct {
+	Side int `vali:"min=0"`
+}
+
+func (s ifaceSquare) Area() int {
+	return s.Side * s.Side
+}
+
+type ifaceMock struct {
+	Any   interface{} `vali:"optional"`
+	Shape ifaceShape  `vali:"required"`
+}
+
+func TestValidateInterface(t *testing.T) {
+	var nilSquare *ifaceSquare
+	tests := []struct {
+		name  string
+		s     *ifaceMock
+		want  error
+		types []string
+	}{
+		{
+			name:  "interfaces hold valid struct pointers, should not error",
+			s:     &ifaceMock{Any: &ifaceSquare{Side: 1}, Shape: &ifaceSquare{Side: 2}},
+			want:  nil,
+			types: []string{"*vali.ifaceSquare", "*vali.ifaceSquare"},
+		},
+		{
+			name: "interfaces hold invalid struct pointers, should validate them by their dynamic type",
+			s:    &ifaceMock{Any: &ifaceSquare{Side: -1}, Shape: &ifaceSquare{Side: -2}},
+			want: newAggErr().addErr(
+				newAggErr().addErr(withType(withPath(newTagError("Side", minTag, errors.New("-1 is less than 0")), "Any.Side"), &ifaceSquare{})),
+				newAggErr().addErr(withType(withPath(newTagError("Side", minTag, errors.New("-2 is less than 0")), "Shape.Side"), &ifaceSquare{})),
+			),
+			types: []string{"*vali.ifaceSquare", "*vali.ifaceSquare"},
+		},
+		{
+			name: "interfaces hold invalid structs, should validate them by their dynamic type",
+			s:    &ifaceMock{Any: ifaceSquare{Side: -1}, Shape: ifaceSquare{Side: -2}},
+			want: newAggErr().addErr(
+				newAggErr().addErr(withType(withPath(newTagError("Side", minTag, errors.New("-1 is less than 0")), "Any.Side"), ifaceSquare{})),
+				newAggErr().addErr(withType(withPath(newTagError("Side", minTag, errors.New("-2 is less than 0")), "Shape.Side"), ifaceSquare{})),
+			),
+			types: []string{"*vali.ifaceSquare", "*vali.ifaceSquare"},
+		},
+		{
+			name:  "interfaces are nil, should only validate their tags",
+			s:     &ifaceMock{},
+			want:  newAggErr().addErr(newTagError("Shape", requiredTag, errors.New("value is nil"))),
+			types: nil,
+		},
+		{
+			name:  "interfaces hold nil pointers, should only validate their tags",
+			s:     &ifaceMock{Any: nilSquare, Shape: nilSquare},
+			want:  newAggErr().addErr(newTagError("Shape", requiredTag, errors.New("value is nil"))),
+			types: nil,
+		},
+		{
+			name:  "interface holds a value that's not a struct, should only validate its tags",
+			s:     &ifaceMock{Any: 5, Shape: &ifaceSquare{Side: 1}},
+			want:  nil,
+			types: []string{"*vali.ifaceSquare"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var types []string
+			v := New()
+			v.SetTypeValidation(ifaceSquare{}, func(s interface{}) error {
+				types = append(types, reflect.TypeOf(s).String())
+				return nil
+			})
+
+			if got := v.Validate(tt.s); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Vali.Validate() = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(types, tt.types) {
+				t.Errorf("type func got %v, want %v", types, tt.types)
+			}
+		})
+	}
+
+	t.Run("struct held by value, should keep the defaults only if it's addressable", func(t *testing.T) {
+		type inner struct {
+			Name string `vali:"default=a"`
+		}
+		s := &struct {
+			Field inner       `vali:"optional"`
+			Any   interface{} `vali:"optional"`
+		}{Any: inner{}}
+		if err := New().Validate(s); err != nil {
+			t.Fatalf("Vali.Validate() = %v", err)
+		}
+		if s.Field.Name != "a" || s.Any.(inner).Name != "" {
+			t.Errorf("Vali.Validate() = %+v, want the default only in Field", s)
+		}
+	})
+
+	t.Run("struct nested in a struct held by an interface, should only report the type of the held struct", func(t *testing.T) {
+		type inner struct {
+			Name string `vali:"required"`
+		}
+		type outer struct {
+			Name  string `vali:"required"`
+			Inner inner  `vali:"optional"`
+		}
+		s := &struct {
+			Any interface{} `vali:"optional"`
+		}{Any: &outer{}}
+		want := newAggErr().addErr(newAggErr().addErr(
+			withType(withPath(newTagError("Name", requiredTag, errors.New("empty string")), "Any.Name"), &outer{}),
+			newAggErr().addErr(withPath(newTagError("Name", requiredTag, errors.New("empty string")), "Any.Inner.Name")),
+		))
+		if got := New().Validate(s); !reflect.DeepEqual(got, want) {
+			t.Errorf("Vali.Validate() = %v, want %v", got, want)
+		}
+		if got, want := want.Error(), "field: 'Name' of '*vali.outer', failed 'required' tag with an error: 'empty string'\nfield: 'Name', failed 'required' tag with an error: 'empty string'"; got != want {
+			t.Errorf("AggErr.Error() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("interface holds a value that can't be compared, should report its dynamic type", func(t *testing.T) {
+		s := &struct {
+			Any interface{} `vali:"eq=a"`
+		}{Any: 5}
+		want := newAggErr().addErr(newTagError("Any", eqTag, typeMismatch(5, "a")))
+		if got := New().Validate(s); !reflect.DeepEqual(got, want) {
+			t.Errorf("Vali.Validate() = %v, want %v", got, want)
+		}
+	})
+}
+
+type EmbedBase struct {
+	ID   int    `vali:"max=10"`
+	Name string `vali:"required"`
+}
+
+type EmbedMeta struct {
+	Limit int
+}
+
+type embedMock struct {
+	EmbedBase
+	*EmbedMeta
+	Name  string `vali:"optional"`
+	Count int    `vali:"max=*Limit"`
+}
+
+type embedKept struct {
+	EmbedBase `vali:"no_flatten"`
+}
+
+func TestValidateEmbedded(t *testing.T) {
+	tests := []struct {
+		name string
+		s    interface{}
+		want error
+	}{
+		{
+			name: "valid embedded fields, should not error",
+			s:    &embedMock{EmbedBase: EmbedBase{ID: 1}, EmbedMeta: &EmbedMeta{Limit: 5}, Name: "abc", Count: 5},
+			want: nil,
+		},
+		{
+			name: "shadowed field is empty, should only validate the outer field",
+			s:    &embedMock{EmbedMeta: &EmbedMeta{Limit: 5}},
+			want: nil,
+		},
+		{
+			name: "invalid promoted field, should report it without the embedded struct in the path",
+			s:    &embedMock{EmbedBase: EmbedBase{ID: 11}, EmbedMeta: &EmbedMeta{Limit: 5}, Count: 5},
+			want: newAggErr().addErr(newTagError("ID", maxTag, errors.New("11 is more than 10"))),
+		},
+		{
+			name: "field pointer to a promoted field, should resolve it",
+			s:    &embedMock{EmbedMeta: &EmbedMeta{Limit: 2}, Count: 3},
+			want: newAggErr().addErr(newTagError("Count", maxTag, errors.New("3 is more than 2"))),
+		},
+		{
+			name: "field pointer to a field of a nil embedded pointer, should be nil",
+			s:    &embedMock{Count: 3},
+			want: newAggErr().addErr(newTagError("Count", maxTag, typeMismatch(3, nil))),
+		},
+		{
+			name: "embedded struct with no_flatten, should validate it as a nested field",
+			s:    &embedKept{EmbedBase: EmbedBase{ID: 11}},
+			want: newAggErr().addErr(newAggErr().addErr(
+				withPath(newTagError("ID", maxTag, errors.New("11 is more than 10")), "EmbedBase.ID"),
+				withPath(newTagError("Name", requiredTag, errors.New("empty string")), "EmbedBase.Name"),
+			)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := New().Validate(tt.s); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Vali.Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("embedded struct with a type validation, should run it", func(t *testing.T) {
+		var got []string
+		v := New()
+		v.SetTypeValidation(EmbedBase{}, func(s interface{}) error {
+			got = append(got, reflect.TypeOf(s).String())
+			return nil
+		})
+		if err := v.Validate(&embedMock{EmbedMeta: &EmbedMeta{}}); err != nil {
+			t.Fatalf("Vali.Validate() = %v", err)
+		}
+		if want := []string{"*vali.EmbedBase"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("type func got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("rules of promoted fields, should be registered on the outer struct", func(t *testing.T) {
+		v := New()
+		if err := v.RegisterStructRules(&embedMock{}, map[string]string{"Limit": "min=1"}); err != nil {
+			t.Fatalf("Vali.RegisterStructRules() = %v", err)
+		}
+		want := newAggErr().addErr(newTagError("Limit", minTag, errors.New("0 is less than 1")))
+		if got := v.Validate(&embedMock{EmbedMeta: &EmbedMeta{}}); !reflect.DeepEqual(got, want) {
+			t.Errorf("Vali.Validate() = %v, want %v", got, want)
+		}
+	})
+}
+
+type pathAddr struct {
+	City string `vali:"required"`
+}
+
+type pathMock struct {
+	EmbedBase
+	Kept struct {
+		EmbedBase `vali:"no_flatten"`
+	} `vali:"required"`
+	Address *pathAddr `vali:"required"`
+	Tags    []string  `vali:"required|>|eq=a"`
+	Grid    [][]int   `vali:"required|>|>|max=1"`
+}
+
+func TestFieldErrorPath(t *testing.T) {
+	s := &pathMock{
+		EmbedBase: EmbedBase{ID: 11, Name: "a"},
+		Address:   &pathAddr{},
+		Tags:      []string{"a", "b"},
+		Grid:      [][]int{{0}, {1, 2}},
+	}
+	s.Kept.Name = "a"
+	s.Kept.ID = 12
+
+	var paths []string
+	var walk func(err error)
+	walk = func(err error) {
+		if agg, ok := err.(*AggErr); ok {
+			for _, e := range agg.Sl {
+				walk(e)
+			}
+			return
+		}
+		if fe, ok := err.(*FieldError); ok {
+			paths = append(paths, fe.Field+" "+fe.Path)
+		}
+	}
+	walk(New().Validate(s))
+
+	want := []string{
+		"ID ID",
+		"ID Kept.EmbedBase.ID",
+		"City Address.City",
+		"Tags Tags[1]",
+		"Grid Grid[1][1]",
+	}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("FieldError paths = %v, want %v", paths, want)
+	}
+}
@@ -0,0 +1,155 @@
+package valicli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/tomasmik/vali"
+)
+
+// Problem is a single error found in a document.
+type Problem struct {
+	// File is the name of the document, `<stdin>` if it was read from stdin.
+	File string `json:"file"`
+	// Line and Column are the position of the value the problem points to,
+	// they start at 1.
+	Line   int `json:"line"`
+	Column int `json:"column"`
+	// Pointer is the JSON pointer of the value, it's empty for the whole document.
+	Pointer string `json:"pointer"`
+	// Tag is the name of the tag that failed,
+	// it's empty if the document could not be decoded.
+	Tag string `json:"tag,omitempty"`
+	// Message describes the problem.
+	Message string `json:"message"`
+}
+
+func (p Problem) String() string {
+	s := fmt.Sprintf("%s:%d:%d: ", p.File, p.Line, p.Column)
+	if p.Pointer != "" {
+		s += p.Pointer + ": "
+	}
+	if p.Tag != "" {
+		s += p.Tag + ": "
+	}
+	return s + p.Message
+}
+
+// Check decodes the JSON document in to a new value of the type and validates it,
+// it returns the problems found in the document.
+// Example:
+/*
+
+	ps := valicli.Check(v, reflect.TypeOf(User{}), "user.json", data)
+	for _, p := range ps {
+		fmt.Println(p)
+	}
+
+*/
+func Check(v *vali.Vali, typ reflect.Type, file string, data []byte) []Problem {
+	pos := positions(data)
+	problem := func(ptr string, offset int, tag, msg string) Problem {
+		if o, ok := pos.lookup(ptr); ok && offset < 0 {
+			offset = o
+		}
+		line, col := lineCol(data, offset)
+		return Problem{File: file, Line: line, Column: col, Pointer: ptr, Tag: tag, Message: msg}
+	}
+
+	ptr := reflect.New(typ)
+	if err := json.Unmarshal(data, ptr.Interface()); err != nil {
+		return []Problem{decodeProblem(err, problem)}
+	}
+
+	err := v.Validate(ptr.Interface())
+	if err == nil {
+		return nil
+	}
+
+	ps := []Problem{}
+	for _, err := range flatten(err) {
+		var fe *vali.FieldError
+		if !errors.As(err, &fe) {
+			ps = append(ps, problem("", -1, "", err.Error()))
+			continue
+		}
+		ps = append(ps, problem(Pointer(typ, fe.Path), -1, fe.Tag, fe.Err.Error()))
+	}
+	return ps
+}
+
+func decodeProblem(err error, problem func(string, int, string, string) Problem) Problem {
+	var syntax *json.SyntaxError
+	if errors.As(err, &syntax) {
+		// The offset is reported after the invalid character
+		return problem("", int(syntax.Offset)-1, "", err.Error())
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		ptr := ""
+		if typeErr.Field != "" {
+			ptr = "/" + strings.Join(escapeAll(strings.Split(typeErr.Field, ".")), "/")
+		}
+		return problem(ptr, -1, "", err.Error())
+	}
+
+	return problem("", -1, "", err.Error())
+}
+
+// flatten returns the errors held by the nested `vali.AggErr` errors.
+func flatten(err error) []error {
+	agg, ok := err.(*vali.AggErr)
+	if !ok {
+		return []error{err}
+	}
+
+	errs := []error{}
+	for _, e := range agg.Sl {
+		errs = append(errs, flatten(e)...)
+	}
+	return errs
+}
+
+// Pointer returns the JSON pointer of the value at the `vali.FieldError` path,
+// the Go field names are replaced by the names used by the `encoding/json` package.
+// Example:
+/*
+
+	valicli.Pointer(reflect.TypeOf(User{}), "Address.Tags[1]") // "/address/tags/1"
+
+*/
+func Pointer(typ reflect.Type, path string) string {
+	var b strings.Builder
+	for _, elem := range (&vali.FieldError{Path: path}).PathBy(typ, jsonName) {
+		if strings.HasPrefix(elem, "[") {
+			elem = strings.Trim(elem, "[]")
+		}
+		b.WriteString("/" + escape(elem))
+	}
+	return b.String()
+}
+
+// jsonName returns the name used by the `encoding/json` package for the field.
+func jsonName(f reflect.StructField) string {
+	name := strings.Split(f.Tag.Get("json"), ",")[0]
+	if name == "" || name == "-" {
+		return f.Name
+	}
+	return name
+}
+
+// escape escapes a JSON pointer reference token as defined by RFC 6901.
+func escape(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
+
+func escapeAll(sl []string) []string {
+	for i, s := range sl {
+		sl[i] = escape(s)
+	}
+	return sl
+}
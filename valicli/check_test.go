@@ -0,0 +1,106 @@
+package valicli
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/tomasmik/vali"
+)
+
+func TestCheck(t *testing.T) {
+	v := vali.New()
+	v.SetTypeValidation(testUser{}, func(s interface{}) error {
+		if u, ok := s.(*testUser); ok && u.Name == "root" {
+			return errors.New("user is not allowed")
+		}
+		return nil
+	})
+	typ := reflect.TypeOf(testUser{})
+
+	tests := []struct {
+		name string
+		data string
+		want []Problem
+	}{
+		{
+			name: "valid document, should have no problems",
+			data: `{"name": "a", "tags": ["a"], "address": {"city": "Vilnius"}}`,
+			want: nil,
+		},
+		{
+			name: "syntax error, should point to it",
+			data: "{\n  \"name\": \"a\",,\n}",
+			want: []Problem{{File: "f", Line: 2, Column: 15, Message: "invalid character ',' looking for beginning of object key string"}},
+		},
+		{
+			name: "type validation fails, should point to the document",
+			data: `{"name": "root", "address": {"city": "Kaunas"}}`,
+			want: []Problem{{File: "f", Line: 1, Column: 1, Message: "user is not allowed"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Check(v, typ, "f", []byte(tt.data)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Check() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPointer(t *testing.T) {
+	type nested struct {
+		Users []*testUser `json:"users/all"`
+		Grid  [][]int
+	}
+	tests := []struct {
+		name string
+		typ  reflect.Type
+		path string
+		want string
+	}{
+		{
+			name: "empty path, should point to the document",
+			typ:  reflect.TypeOf(testUser{}),
+			path: "",
+			want: "",
+		},
+		{
+			name: "nested field, should use json names",
+			typ:  reflect.TypeOf(testUser{}),
+			path: "Address.City",
+			want: "/address/city",
+		},
+		{
+			name: "dive element, should add the index",
+			typ:  reflect.TypeOf(testUser{}),
+			path: "Tags[1]",
+			want: "/tags/1",
+		},
+		{
+			name: "field of a slice element, should escape the name",
+			typ:  reflect.TypeOf(nested{}),
+			path: "Users[2].Address.City",
+			want: "/users~1all/2/address/city",
+		},
+		{
+			name: "field without a json tag, should use the field name",
+			typ:  reflect.TypeOf(nested{}),
+			path: "Grid[1][0]",
+			want: "/Grid/1/0",
+		},
+		{
+			name: "unknown field, should keep the name",
+			typ:  reflect.TypeOf(testUser{}),
+			path: "Unknown.Field",
+			want: "/Unknown/Field",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Pointer(tt.typ, tt.path); got != tt.want {
+				t.Errorf("Pointer() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package valicli
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+const (
+	exitOK     = 0
+	exitFailed = 1
+	exitUsage  = 2
+
+	stdinName = "<stdin>"
+)
+
+// Main runs the command with the given arguments and returns its exit code,
+// it's 1 if any of the documents failed to validate and 2 if the arguments are invalid.
+//
+// Usage:
+//
+//	vali [-json] Type [file ...]
+//
+// The documents are read from stdin if no files are given or the file is `-`.
+func Main(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("vali", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	asJSON := fs.Bool("json", false, "print the problems as a JSON array")
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "usage: vali [-json] Type [file ...]\n\nregistered types: %s\n\nflags:\n", strings.Join(names(), ", "))
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	typ, ok := lookup(fs.Arg(0))
+	if !ok {
+		fmt.Fprintf(stderr, "vali: unknown type '%s', registered types: %s\n", fs.Arg(0), strings.Join(names(), ", "))
+		return exitUsage
+	}
+
+	files := fs.Args()[1:]
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+
+	code := exitOK
+	problems := []Problem{}
+	for _, f := range files {
+		name, data, err := readFile(f, stdin)
+		if err != nil {
+			fmt.Fprintf(stderr, "vali: %v\n", err)
+			code = exitFailed
+			continue
+		}
+
+		ps := Check(getValidator(), typ, name, data)
+		if len(ps) > 0 {
+			code = exitFailed
+		}
+		problems = append(problems, ps...)
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(problems); err != nil {
+			fmt.Fprintf(stderr, "vali: %v\n", err)
+			return exitFailed
+		}
+		return code
+	}
+
+	for _, p := range problems {
+		fmt.Fprintln(stdout, p.String())
+	}
+	return code
+}
+
+func readFile(name string, stdin io.Reader) (string, []byte, error) {
+	if name == "-" {
+		if stdin == nil {
+			return stdinName, nil, errors.New("stdin is not available")
+		}
+		data, err := io.ReadAll(stdin)
+		return stdinName, data, err
+	}
+
+	data, err := os.ReadFile(name)
+	return name, data, err
+}
@@ -0,0 +1,103 @@
+package valicli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMainCommand(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.json")
+	invalid := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(valid, []byte(`{"name": "a", "address": {"city": "Vilnius"}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(invalid, []byte("{\n  \"name\": \"abcdef\",\n  \"address\": {}\n}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		stdin      string
+		wantCode   int
+		wantStdout string
+	}{
+		{
+			name:     "no arguments, should exit with usage",
+			args:     []string{},
+			wantCode: exitUsage,
+		},
+		{
+			name:     "unknown type, should exit with usage",
+			args:     []string{"Unknown"},
+			wantCode: exitUsage,
+		},
+		{
+			name:     "unknown flag, should exit with usage",
+			args:     []string{"-unknown", "User"},
+			wantCode: exitUsage,
+		},
+		{
+			name:     "valid file, should exit ok",
+			args:     []string{"User", valid},
+			wantCode: exitOK,
+		},
+		{
+			name:     "file doesn't exist, should fail",
+			args:     []string{"User", filepath.Join(dir, "missing.json")},
+			wantCode: exitFailed,
+		},
+		{
+			name:     "invalid file, should print the problems",
+			args:     []string{"User", valid, invalid},
+			wantCode: exitFailed,
+			wantStdout: invalid + ":2:11: /name: max: abcdef is more than 5\n" +
+				invalid + ":3:14: /address/city: required: empty string\n",
+		},
+		{
+			name:       "invalid stdin, should print the problems",
+			args:       []string{"User"},
+			stdin:      `{"address": {"city": "Riga"}, "tags": ["a", "c"]}`,
+			wantCode:   exitFailed,
+			wantStdout: "<stdin>:1:1: /name: required: empty string\n<stdin>:1:45: /tags/1: one_of: must have at least one of [a b]\n<stdin>:1:22: /address/city: one_of: must have at least one of [Vilnius Kaunas]\n",
+		},
+		{
+			name:     "invalid stdin as json, should print a json array",
+			args:     []string{"-json", "User", "-"},
+			stdin:    `{"name": 1}`,
+			wantCode: exitFailed,
+			wantStdout: `[
+  {
+    "file": "<stdin>",
+    "line": 1,
+    "column": 10,
+    "pointer": "/name",
+    "message": "json: cannot unmarshal number into Go struct field testUser.name of type string"
+  }
+]
+`,
+		},
+		{
+			name:       "valid stdin as json, should print an empty array",
+			args:       []string{"-json", "User"},
+			stdin:      `{"name": "a", "address": {"city": "Kaunas"}}`,
+			wantCode:   exitOK,
+			wantStdout: "[]\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+			if got := Main(tt.args, strings.NewReader(tt.stdin), stdout, stderr); got != tt.wantCode {
+				t.Errorf("Main() = %v, want %v, stderr %s", got, tt.wantCode, stderr)
+			}
+			if got := stdout.String(); got != tt.wantStdout {
+				t.Errorf("Main() stdout = %q, want %q", got, tt.wantStdout)
+			}
+		})
+	}
+}
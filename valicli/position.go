@@ -0,0 +1,99 @@
+package valicli
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// offsets holds the offset of every value in a document by its JSON pointer.
+type offsets map[string]int
+
+// lookup returns the offset of the value, or of its closest parent
+// if the document doesn't have it, for example when a required field is missing.
+func (o offsets) lookup(ptr string) (int, bool) {
+	for {
+		if off, ok := o[ptr]; ok {
+			return off, true
+		}
+		i := strings.LastIndexByte(ptr, '/')
+		if i < 0 {
+			return 0, false
+		}
+		ptr = ptr[:i]
+	}
+}
+
+// positions returns the offsets of the values in the document,
+// the values after a syntax error are not included.
+func positions(data []byte) offsets {
+	o := offsets{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	var walk func(ptr string) error
+	walk = func(ptr string) error {
+		o[ptr] = skip(data, int(dec.InputOffset()))
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		d, ok := tok.(json.Delim)
+		if !ok {
+			return nil
+		}
+		switch d {
+		case '{':
+			for dec.More() {
+				key, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				if err := walk(ptr + "/" + escape(key.(string))); err != nil {
+					return err
+				}
+			}
+		case '[':
+			for i := 0; dec.More(); i++ {
+				if err := walk(ptr + "/" + strconv.Itoa(i)); err != nil {
+					return err
+				}
+			}
+		}
+		_, err = dec.Token()
+		return err
+	}
+
+	_ = walk("")
+	return o
+}
+
+// skip returns the offset of the next value, the decoder
+// reports the offset before the separators of the value.
+func skip(data []byte, offset int) int {
+	for offset < len(data) {
+		switch data[offset] {
+		case ' ', '\t', '\r', '\n', ':', ',':
+			offset++
+		default:
+			return offset
+		}
+	}
+	return offset
+}
+
+// lineCol returns the line and column of the offset, both start at 1.
+func lineCol(data []byte, offset int) (int, int) {
+	if offset < 0 {
+		return 1, 1
+	}
+	if offset > len(data) {
+		offset = len(data)
+	}
+
+	before := data[:offset]
+	line := bytes.Count(before, []byte{'\n'}) + 1
+	col := offset - bytes.LastIndexByte(before, '\n')
+	return line, col
+}
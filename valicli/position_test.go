@@ -0,0 +1,81 @@
+package valicli
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPositions(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want offsets
+	}{
+		{
+			name: "nested document, should have every value",
+			data: "{\"a\": {\"b\": [1, {\"c~\": 2}]},\n \"d/\": null}",
+			want: offsets{"": 0, "/a": 6, "/a/b": 12, "/a/b/0": 13, "/a/b/1": 16, "/a/b/1/c~0": 23, "/d~1": 36},
+		},
+		{
+			name: "syntax error, should have the values before it",
+			data: `{"a": 1, "b": }`,
+			want: offsets{"": 0, "/a": 6, "/b": 14},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := positions([]byte(tt.data)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("positions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOffsetsLookup(t *testing.T) {
+	o := offsets{"": 0, "/a": 6}
+	tests := []struct {
+		name   string
+		ptr    string
+		want   int
+		wantOk bool
+	}{
+		{name: "value exists, should return it", ptr: "/a", want: 6, wantOk: true},
+		{name: "value is missing, should return the parent", ptr: "/a/b/c", want: 6, wantOk: true},
+		{name: "top level value is missing, should return the document", ptr: "/b", want: 0, wantOk: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := o.lookup(tt.ptr)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("offsets.lookup() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+
+	if _, ok := (offsets{}).lookup("/a"); ok {
+		t.Errorf("offsets.lookup() of an empty document should not be found")
+	}
+}
+
+func TestLineCol(t *testing.T) {
+	data := []byte("ab\ncd\n")
+	tests := []struct {
+		name     string
+		offset   int
+		wantLine int
+		wantCol  int
+	}{
+		{name: "start, should be the first column", offset: 0, wantLine: 1, wantCol: 1},
+		{name: "second line, should count the newlines", offset: 4, wantLine: 2, wantCol: 2},
+		{name: "negative offset, should be the start", offset: -1, wantLine: 1, wantCol: 1},
+		{name: "offset past the end, should be the end", offset: 100, wantLine: 3, wantCol: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line, col := lineCol(data, tt.offset)
+			if line != tt.wantLine || col != tt.wantCol {
+				t.Errorf("lineCol() = %v, %v, want %v, %v", line, col, tt.wantLine, tt.wantCol)
+			}
+		})
+	}
+}
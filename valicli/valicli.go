@@ -0,0 +1,101 @@
+// Package valicli implements the `vali` command, which validates
+// JSON documents against the types registered with `Register`.
+//
+// YAML documents are not supported, decoding them with the positions of their
+// values needs a YAML parser and the vali module has no dependencies.
+// They can be converted to JSON before they're validated.
+//
+// Types are linked in to the command by building a small main package
+// which imports the packages that register them:
+//
+//	package main
+//
+//	import (
+//		"os"
+//
+//		_ "example.com/app/fixtures"
+//		"github.com/tomasmik/vali/valicli"
+//	)
+//
+//	func main() {
+//		os.Exit(valicli.Main(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+//	}
+package valicli
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/tomasmik/vali"
+)
+
+var (
+	mu        sync.RWMutex
+	types     = map[string]reflect.Type{}
+	validator *vali.Vali
+)
+
+// Register makes the type available to the command under the given name,
+// it's expected to be called from the `init()` func of the package holding the type.
+// Register panics if the name is already used or the type is not a struct.
+// Example:
+/*
+
+	func init() {
+		valicli.Register("User", User{})
+	}
+
+*/
+func Register(name string, typ interface{}) {
+	t := reflect.TypeOf(typ)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("valicli: type %s registered as '%s' is not a struct", reflect.TypeOf(typ), name))
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if _, ok := types[name]; ok {
+		panic(fmt.Sprintf("valicli: type '%s' is already registered", name))
+	}
+	types[name] = t
+}
+
+// SetValidator sets the validator used to validate the documents,
+// the validator returned by `vali.DefaultVali` is used if it's not set.
+func SetValidator(v *vali.Vali) {
+	mu.Lock()
+	defer mu.Unlock()
+	validator = v
+}
+
+func lookup(name string) (reflect.Type, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	t, ok := types[name]
+	return t, ok
+}
+
+func names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	ns := make([]string, 0, len(types))
+	for n := range types {
+		ns = append(ns, n)
+	}
+	sort.Strings(ns)
+	return ns
+}
+
+func getValidator() *vali.Vali {
+	mu.RLock()
+	defer mu.RUnlock()
+	if validator == nil {
+		return vali.DefaultVali()
+	}
+	return validator
+}
@@ -0,0 +1,60 @@
+package valicli
+
+import (
+	"testing"
+)
+
+type testAddress struct {
+	City string `json:"city" vali:"required|one_of=Vilnius,Kaunas"`
+}
+
+type testUser struct {
+	Name    string       `json:"name" vali:"required|max=5"`
+	Tags    []string     `json:"tags" vali:">|one_of=a,b"`
+	Address *testAddress `json:"address" vali:"required"`
+}
+
+func init() {
+	Register("User", testUser{})
+}
+
+func TestRegister(t *testing.T) {
+	tests := []struct {
+		name      string
+		typ       interface{}
+		wantPanic bool
+	}{
+		{
+			name:      "name is already registered, should panic",
+			typ:       testUser{},
+			wantPanic: true,
+		},
+		{
+			name:      "type is not a struct, should panic",
+			typ:       "",
+			wantPanic: true,
+		},
+		{
+			name:      "nil, should panic",
+			typ:       nil,
+			wantPanic: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); (r != nil) != tt.wantPanic {
+					t.Errorf("Register() panic = %v, wantPanic %v", r, tt.wantPanic)
+				}
+			}()
+			Register("User", tt.typ)
+		})
+	}
+
+	t.Run("pointer to a struct, should register the struct", func(t *testing.T) {
+		Register("UserPtr", (*testUser)(nil))
+		if typ, ok := lookup("UserPtr"); !ok || typ.Name() != "testUser" {
+			t.Errorf("lookup() = %v, %v, want testUser", typ, ok)
+		}
+	})
+}
@@ -0,0 +1,124 @@
+// Package valigrpc defines gRPC server interceptors which validate
+// the request messages with vali.
+//
+// Messages that don't pass the validation are rejected with the
+// `codes.InvalidArgument` status, which has the failed fields
+// as the field violations of an `errdetails.BadRequest` detail.
+// Generated messages have no vali tags, their rules can be added
+// with `SetRules` or `RegisterStructRules`.
+package valigrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/tomasmik/vali"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor returns an interceptor which validates the requests
+// of unary calls with the validator before they're passed to the handler.
+// Example:
+/*
+
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(valigrpc.UnaryServerInterceptor(v)),
+		grpc.ChainStreamInterceptor(valigrpc.StreamServerInterceptor(v)),
+	)
+
+*/
+func UnaryServerInterceptor(v *vali.Vali) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := v.Validate(req); err != nil {
+			return nil, Error(req, err)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns an interceptor which validates every message
+// received by the stream handler with the validator.
+func StreamServerInterceptor(v *vali.Vali) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &serverStream{ServerStream: ss, v: v})
+	}
+}
+
+// serverStream validates the messages it receives.
+type serverStream struct {
+	grpc.ServerStream
+	v *vali.Vali
+}
+
+func (s *serverStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if err := s.v.Validate(m); err != nil {
+		return Error(m, err)
+	}
+	return nil
+}
+
+// Error returns the `codes.InvalidArgument` status error of the validation error of the message,
+// the failed fields are named by their protobuf field names - "address.city", "tags[1]".
+func Error(msg interface{}, err error) error {
+	br := &errdetails.BadRequest{}
+	for _, e := range flatten(err) {
+		var fe *vali.FieldError
+		if !errors.As(e, &fe) {
+			br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Description: e.Error(),
+			})
+			continue
+		}
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       fieldPath(reflect.TypeOf(msg), fe.Path),
+			Description: fmt.Sprintf("%s: %v", fe.Tag, fe.Err),
+		})
+	}
+
+	st, sErr := status.New(codes.InvalidArgument, "request validation failed").WithDetails(br)
+	if sErr != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return st.Err()
+}
+
+// flatten returns the errors held by the nested `vali.AggErr` errors.
+func flatten(err error) []error {
+	agg, ok := err.(*vali.AggErr)
+	if !ok {
+		return []error{err}
+	}
+
+	errs := []error{}
+	for _, e := range agg.Sl {
+		errs = append(errs, flatten(e)...)
+	}
+	return errs
+}
+
+// fieldPath replaces the Go field names of the `vali.FieldError` path
+// with the protobuf names of the fields.
+func fieldPath(typ reflect.Type, path string) string {
+	elems := (&vali.FieldError{Path: path}).PathBy(typ, protoName)
+	return strings.ReplaceAll(strings.Join(elems, "."), ".[", "[")
+}
+
+// protoName returns the name of the field from its `protobuf` tag,
+// fields without one use their Go name.
+func protoName(f reflect.StructField) string {
+	for _, opt := range strings.Split(f.Tag.Get("protobuf"), ",") {
+		if strings.HasPrefix(opt, "name=") {
+			return strings.TrimPrefix(opt, "name=")
+		}
+	}
+	return f.Name
+}
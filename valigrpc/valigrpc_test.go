@@ -0,0 +1,247 @@
+package valigrpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/tomasmik/vali"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var testDesc = grpc.ServiceDesc{
+	ServiceName: "vali.Test",
+	HandlerType: (*interface{})(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Echo",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				in := &descriptorpb.FileDescriptorProto{}
+				if err := dec(in); err != nil {
+					return nil, err
+				}
+				handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+					return req, nil
+				}
+				info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/vali.Test/Echo"}
+				return interceptor(ctx, in, info, handler)
+			},
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Count",
+			ClientStreams: true,
+			Handler: func(srv interface{}, stream grpc.ServerStream) error {
+				n := int32(0)
+				for {
+					err := stream.RecvMsg(&wrapperspb.StringValue{})
+					if errors.Is(err, io.EOF) {
+						return stream.SendMsg(wrapperspb.Int32(n))
+					}
+					if err != nil {
+						return err
+					}
+					n++
+				}
+			},
+		},
+	},
+}
+
+func newTestConn(t *testing.T) *grpc.ClientConn {
+	t.Helper()
+	v := vali.New()
+	rules := map[interface{}]map[string]string{
+		&descriptorpb.FileDescriptorProto{}: {"Name": "required|max=5", "Dependency": ">|one_of=a,b", "Options": "required"},
+		&descriptorpb.FileOptions{}:         {"JavaPackage": "required"},
+		&wrapperspb.StringValue{}:           {"Value": "required"},
+	}
+	for typ, fields := range rules {
+		if err := v.RegisterStructRules(typ, fields); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(UnaryServerInterceptor(v)),
+		grpc.ChainStreamInterceptor(StreamServerInterceptor(v)),
+	)
+	s.RegisterService(&testDesc, struct{}{})
+	go func() {
+		_ = s.Serve(lis)
+	}()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return conn
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	conn := newTestConn(t)
+	tests := []struct {
+		name     string
+		req      *descriptorpb.FileDescriptorProto
+		wantCode codes.Code
+		want     []*errdetails.BadRequest_FieldViolation
+	}{
+		{
+			name: "valid request, should be handled",
+			req: &descriptorpb.FileDescriptorProto{
+				Name:       proto.String("a"),
+				Dependency: []string{"a"},
+				Options:    &descriptorpb.FileOptions{JavaPackage: proto.String("a")},
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "invalid request, should have the field violations",
+			req: &descriptorpb.FileDescriptorProto{
+				Name:       proto.String("abcdef"),
+				Dependency: []string{"a", "c"},
+				Options:    &descriptorpb.FileOptions{},
+			},
+			wantCode: codes.InvalidArgument,
+			want: []*errdetails.BadRequest_FieldViolation{
+				{Field: "name", Description: "max: abcdef is more than 5"},
+				{Field: "dependency[1]", Description: "one_of: must have at least one of [a b]"},
+				{Field: "options.java_package", Description: "required: value is nil"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &descriptorpb.FileDescriptorProto{}
+			err := conn.Invoke(context.Background(), "/vali.Test/Echo", tt.req, out)
+			st := status.Convert(err)
+			if st.Code() != tt.wantCode {
+				t.Fatalf("Invoke() code = %v, want %v, err %v", st.Code(), tt.wantCode, err)
+			}
+			if err == nil {
+				if !proto.Equal(out, tt.req) {
+					t.Errorf("Invoke() = %v, want %v", out, tt.req)
+				}
+				return
+			}
+			assertViolations(t, st, tt.want)
+		})
+	}
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	conn := newTestConn(t)
+	tests := []struct {
+		name     string
+		msgs     []string
+		wantCode codes.Code
+		want     []*errdetails.BadRequest_FieldViolation
+	}{
+		{
+			name:     "valid messages, should be handled",
+			msgs:     []string{"a", "b"},
+			wantCode: codes.OK,
+		},
+		{
+			name:     "invalid message, should end the stream with the field violations",
+			msgs:     []string{"a", ""},
+			wantCode: codes.InvalidArgument,
+			want:     []*errdetails.BadRequest_FieldViolation{{Field: "value", Description: "required: empty string"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream, err := conn.NewStream(context.Background(), &testDesc.Streams[0], "/vali.Test/Count")
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, m := range tt.msgs {
+				if err := stream.SendMsg(wrapperspb.String(m)); err != nil {
+					break
+				}
+			}
+			if err := stream.CloseSend(); err != nil {
+				t.Fatal(err)
+			}
+
+			out := &wrapperspb.Int32Value{}
+			err = stream.RecvMsg(out)
+			st := status.Convert(err)
+			if st.Code() != tt.wantCode {
+				t.Fatalf("RecvMsg() code = %v, want %v, err %v", st.Code(), tt.wantCode, err)
+			}
+			if err == nil {
+				if out.GetValue() != int32(len(tt.msgs)) {
+					t.Errorf("RecvMsg() = %v, want %v", out.GetValue(), len(tt.msgs))
+				}
+				return
+			}
+			assertViolations(t, st, tt.want)
+		})
+	}
+}
+
+func assertViolations(t *testing.T, st *status.Status, want []*errdetails.BadRequest_FieldViolation) {
+	t.Helper()
+	if len(st.Details()) != 1 {
+		t.Fatalf("status details = %v, want a single BadRequest", st.Details())
+	}
+	br, ok := st.Details()[0].(*errdetails.BadRequest)
+	if !ok {
+		t.Fatalf("status detail = %T, want *errdetails.BadRequest", st.Details()[0])
+	}
+	if !proto.Equal(br, &errdetails.BadRequest{FieldViolations: want}) {
+		t.Errorf("field violations = %v, want %v", br.FieldViolations, want)
+	}
+}
+
+func TestError(t *testing.T) {
+	err := Error(&descriptorpb.FileDescriptorProto{}, errors.New("struct is nil"))
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Errorf("Error() code = %v, want %v", st.Code(), codes.InvalidArgument)
+	}
+	assertViolations(t, st, []*errdetails.BadRequest_FieldViolation{{Description: "struct is nil"}})
+}
+
+func TestFieldPath(t *testing.T) {
+	typ := reflect.TypeOf(&descriptorpb.FileDescriptorProto{})
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{name: "empty path, should be empty", path: "", want: ""},
+		{name: "nested message, should use the protobuf names", path: "MessageType[1].Field[0].JsonName", want: "message_type[1].field[0].json_name"},
+		{name: "unknown field, should keep the name", path: "Unknown.Name", want: "Unknown.Name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fieldPath(typ, tt.path); got != tt.want {
+				t.Errorf("fieldPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package valihttp
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// valuesKeys are the struct tag keys holding the names of the form and query parameters,
+// they're the same keys `vali.BindValues` uses.
+var valuesKeys = []string{"form", "query"}
+
+func decodeJSON(r *http.Request, dst interface{}) error {
+	dec := json.NewDecoder(r.Body)
+	if err := dec.Decode(dst); err != nil {
+		if errors.Is(err, io.EOF) {
+			return errors.New("request body is empty")
+		}
+		return err
+	}
+	if dec.More() {
+		return errors.New("request body must only have a single JSON value")
+	}
+	return nil
+}
+
+// formValues returns the decoder values func of the urlencoded and multipart forms,
+// `maxBytes` of a multipart form are kept in memory and the rest of its files are stored
+// on disk. A negative `maxBytes` keeps the whole form in memory.
+func formValues(maxBytes int64) func(r *http.Request) (url.Values, error) {
+	if maxBytes < 0 {
+		maxBytes = math.MaxInt64
+	}
+
+	return func(r *http.Request) (url.Values, error) {
+		if strings.HasPrefix(r.Header.Get("Content-Type"), mediaMultipart) {
+			if err := r.ParseMultipartForm(maxBytes); err != nil {
+				return nil, err
+			}
+			return url.Values(r.MultipartForm.Value), nil
+		}
+
+		if err := r.ParseForm(); err != nil {
+			return nil, err
+		}
+		return r.PostForm, nil
+	}
+}
+
+func queryValues(r *http.Request) (url.Values, error) {
+	return r.URL.Query(), nil
+}
+
+// tagName returns the name of the field in the first of the struct tags it has.
+func tagName(f reflect.StructField, keys []string) string {
+	for _, k := range keys {
+		if name := strings.Split(f.Tag.Get(k), ",")[0]; name != "" {
+			return name
+		}
+	}
+	return f.Name
+}
@@ -0,0 +1,91 @@
+package valihttp
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFormValues(t *testing.T) {
+	body := &bytes.Buffer{}
+	mw := multipart.NewWriter(body)
+	_ = mw.WriteField("user_name", "a")
+	_ = mw.WriteField("tags", "a")
+	_ = mw.WriteField("tags", "b")
+	_ = mw.Close()
+
+	multi := httptest.NewRequest(http.MethodPost, "/?q=a", body)
+	multi.Header.Set("Content-Type", mw.FormDataContentType())
+	form := httptest.NewRequest(http.MethodPost, "/?q=a", strings.NewReader("user_name=a&tags=a&tags=b"))
+	form.Header.Set("Content-Type", mediaForm)
+
+	tests := []struct {
+		name string
+		r    *http.Request
+	}{
+		{name: "multipart form, should have the form values", r: multi},
+		{name: "urlencoded form, should have the form values", r: form},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := formValues(DefaultMaxBodyBytes)(tt.r)
+			if err != nil {
+				t.Fatalf("formValues() error = %v", err)
+			}
+			if want := (url.Values{"user_name": {"a"}, "tags": {"a", "b"}}); !reflect.DeepEqual(got, want) {
+				t.Errorf("formValues() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestFormValuesLimit(t *testing.T) {
+	// Multipart forms have 10MB on top of the limit for their values
+	value := strings.Repeat("a", DefaultMaxBodyBytes+10<<20+1)
+	newRequest := func() *http.Request {
+		body := &bytes.Buffer{}
+		mw := multipart.NewWriter(body)
+		_ = mw.WriteField("bio", value)
+		_ = mw.Close()
+
+		r := httptest.NewRequest(http.MethodPost, "/", body)
+		r.Header.Set("Content-Type", mw.FormDataContentType())
+		return r
+	}
+
+	tests := []struct {
+		name     string
+		maxBytes int64
+		wantErr  bool
+	}{
+		{name: "default limit, should error", maxBytes: DefaultMaxBodyBytes, wantErr: true},
+		{name: "raised limit, should have the form values", maxBytes: 2 * DefaultMaxBodyBytes, wantErr: false},
+		{name: "no limit, should have the form values", maxBytes: -1, wantErr: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := formValues(tt.maxBytes)(newRequest())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("formValues() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got.Get("bio") != value {
+				t.Errorf("formValues() bio has %d bytes, want %d", len(got.Get("bio")), len(value))
+			}
+		})
+	}
+
+	t.Run("binder with a raised limit, should bind the form", func(t *testing.T) {
+		type profile struct {
+			Bio string `form:"bio" vali:"required"`
+		}
+		b := New(Options{MaxBodyBytes: 16 << 20})
+		if got, err := BindWith[profile](b, newRequest()); err != nil || got.Bio != value {
+			t.Errorf("BindWith() error = %v", err)
+		}
+	})
+}
@@ -0,0 +1,42 @@
+package valihttp
+
+import (
+	"context"
+	"net/http"
+)
+
+// ctxKey is the context key of the bound `T`.
+type ctxKey[T any] struct{}
+
+// Middleware binds the requests to a new `T` with the binder, the ones that fail
+// are answered with the problem details, the rest are passed to the next handler
+// with the bound value which is returned by `FromContext`.
+// Example:
+/*
+
+	h := valihttp.Middleware[User](valihttp.New(valihttp.Options{}))(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			u, _ := valihttp.FromContext[User](r.Context())
+			...
+		},
+	))
+
+*/
+func Middleware[T any](b *Binder) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t, err := BindWith[T](b, r)
+			if err != nil {
+				WriteProblem(w, r, err)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), ctxKey[T]{}, t)))
+		})
+	}
+}
+
+// FromContext returns the `T` bound by `Middleware`.
+func FromContext[T any](ctx context.Context) (*T, bool) {
+	t, ok := ctx.Value(ctxKey[T]{}).(*T)
+	return t, ok
+}
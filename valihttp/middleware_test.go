@@ -0,0 +1,57 @@
+package valihttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMiddleware(t *testing.T) {
+	h := Middleware[testUser](New(Options{}))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		u, ok := FromContext[testUser](r.Context())
+		if !ok {
+			t.Fatal("FromContext() should have the bound value")
+		}
+		_, _ = w.Write([]byte(u.Name))
+	}))
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "valid body, should call the next handler",
+			body:       `{"name": "abc", "age": 18}`,
+			wantStatus: http.StatusOK,
+			wantBody:   "abc",
+		},
+		{
+			name:       "invalid body, should write the problem",
+			body:       `{"age": 18}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `"invalid-params":[{"name":"name","tag":"required","reason":"empty string"}]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			r.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("Middleware() status = %v, want %v", w.Code, tt.wantStatus)
+			}
+			if !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("Middleware() body = %v, want %v", w.Body.String(), tt.wantBody)
+			}
+		})
+	}
+
+	if _, ok := FromContext[testUser](httptest.NewRequest(http.MethodGet, "/", nil).Context()); ok {
+		t.Errorf("FromContext() of an unbound request should not be found")
+	}
+}
@@ -0,0 +1,113 @@
+package valihttp
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/tomasmik/vali"
+)
+
+const mediaProblem = "application/problem+json"
+
+// Error is returned when a request can't be decoded or doesn't pass the validation.
+type Error struct {
+	// Status is the HTTP status code of the response.
+	Status int
+	// Err is the decoding error or the error returned by `Validate`.
+	Err error
+	// Params holds the fields which failed the validation.
+	Params []InvalidParam
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the decoding or the validation error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// InvalidParam is a field of the request that failed the validation.
+type InvalidParam struct {
+	// Name is the path of the field using the names of the fields in the request,
+	// for example `address.city` or `tags[1]`. It's empty for errors not tied to a field.
+	Name string `json:"name"`
+	// Tag is the name of the tag that failed.
+	Tag string `json:"tag,omitempty"`
+	// Reason is the error returned by the tag.
+	Reason string `json:"reason"`
+}
+
+// Problem is the RFC 7807 problem details object written for failed requests.
+type Problem struct {
+	Type          string         `json:"type"`
+	Title         string         `json:"title"`
+	Status        int            `json:"status"`
+	Detail        string         `json:"detail,omitempty"`
+	Instance      string         `json:"instance,omitempty"`
+	InvalidParams []InvalidParam `json:"invalid-params,omitempty"`
+}
+
+// NewProblem returns the problem details of the error, errors which are not
+// `*Error` are reported as internal server errors without exposing their message.
+func NewProblem(r *http.Request, err error) Problem {
+	p := Problem{
+		Type:   "about:blank",
+		Status: http.StatusInternalServerError,
+	}
+	if r != nil {
+		p.Instance = r.URL.Path
+	}
+
+	var e *Error
+	if errors.As(err, &e) {
+		p.Status = e.Status
+		p.InvalidParams = e.Params
+		p.Detail = e.Err.Error()
+		if len(e.Params) > 0 {
+			p.Detail = "the request has invalid fields"
+		}
+	}
+	p.Title = http.StatusText(p.Status)
+	return p
+}
+
+// WriteProblem writes the problem details of the error as the response.
+func WriteProblem(w http.ResponseWriter, r *http.Request, err error) {
+	p := NewProblem(r, err)
+	w.Header().Set("Content-Type", mediaProblem)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(p.Status)
+	_ = json.NewEncoder(w).Encode(p)
+}
+
+// invalidParams returns the fields of the validation error.
+func invalidParams(typ reflect.Type, err error, keys []string) []InvalidParam {
+	agg, ok := err.(*vali.AggErr)
+	if !ok {
+		var fe *vali.FieldError
+		if errors.As(err, &fe) {
+			return []InvalidParam{{Name: paramName(typ, fe.Path, keys), Tag: fe.Tag, Reason: fe.Err.Error()}}
+		}
+		return []InvalidParam{{Reason: err.Error()}}
+	}
+
+	ps := []InvalidParam{}
+	for _, e := range agg.Sl {
+		ps = append(ps, invalidParams(typ, e, keys)...)
+	}
+	return ps
+}
+
+// paramName replaces the Go field names of the `vali.FieldError` path
+// with the names of the fields in the request.
+func paramName(typ reflect.Type, path string, keys []string) string {
+	elems := (&vali.FieldError{Path: path}).PathBy(typ, func(f reflect.StructField) string {
+		return tagName(f, keys)
+	})
+	return strings.ReplaceAll(strings.Join(elems, "."), ".[", "[")
+}
@@ -0,0 +1,121 @@
+package valihttp
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestNewProblem(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/users?a=b", nil)
+	params := []InvalidParam{{Name: "name", Tag: "required", Reason: "empty string"}}
+	tests := []struct {
+		name string
+		err  error
+		want Problem
+	}{
+		{
+			name: "validation error, should have the invalid params",
+			err:  &Error{Status: http.StatusBadRequest, Err: errors.New("a"), Params: params},
+			want: Problem{
+				Type:          "about:blank",
+				Title:         "Bad Request",
+				Status:        http.StatusBadRequest,
+				Detail:        "the request has invalid fields",
+				Instance:      "/users",
+				InvalidParams: params,
+			},
+		},
+		{
+			name: "decoding error, should have the error as the detail",
+			err:  &Error{Status: http.StatusUnsupportedMediaType, Err: errors.New("unsupported content type text/plain")},
+			want: Problem{
+				Type:     "about:blank",
+				Title:    "Unsupported Media Type",
+				Status:   http.StatusUnsupportedMediaType,
+				Detail:   "unsupported content type text/plain",
+				Instance: "/users",
+			},
+		},
+		{
+			name: "unknown error, should not expose it",
+			err:  errors.New("secret"),
+			want: Problem{
+				Type:     "about:blank",
+				Title:    "Internal Server Error",
+				Status:   http.StatusInternalServerError,
+				Instance: "/users",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewProblem(r, tt.err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewProblem() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteProblem(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/users", nil)
+	w := httptest.NewRecorder()
+	WriteProblem(w, r, &Error{
+		Status: http.StatusBadRequest,
+		Err:    errors.New("a"),
+		Params: []InvalidParam{{Name: "tags[1]", Tag: "one_of", Reason: "must have at least one of [a b]"}},
+	})
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("WriteProblem() status = %v, want %v", w.Code, http.StatusBadRequest)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/problem+json" {
+		t.Errorf("WriteProblem() content type = %v, want application/problem+json", ct)
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"type":     "about:blank",
+		"title":    "Bad Request",
+		"status":   float64(http.StatusBadRequest),
+		"detail":   "the request has invalid fields",
+		"instance": "/users",
+		"invalid-params": []interface{}{
+			map[string]interface{}{"name": "tags[1]", "tag": "one_of", "reason": "must have at least one of [a b]"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("WriteProblem() body = %v, want %v", got, want)
+	}
+}
+
+func TestParamName(t *testing.T) {
+	type nested struct {
+		Users [][]*testUser `json:"users"`
+		Skip  string        `json:"-" form:"skip"`
+	}
+	tests := []struct {
+		name string
+		path string
+		keys []string
+		want string
+	}{
+		{name: "empty path, should be empty", path: "", keys: []string{"json"}, want: ""},
+		{name: "nested slices, should use the element names", path: "Users[1][0].Address.City", keys: []string{"json"}, want: "users[1][0].address.city"},
+		{name: "first key found, should be used", path: "Skip", keys: valuesKeys, want: "skip"},
+		{name: "unknown field, should keep the name", path: "Unknown.Name", keys: []string{"json"}, want: "Unknown.Name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := paramName(reflect.TypeOf(&nested{}), tt.path, tt.keys); got != tt.want {
+				t.Errorf("paramName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
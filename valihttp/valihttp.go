@@ -0,0 +1,233 @@
+// Package valihttp decodes the bodies, forms and query parameters
+// of HTTP requests in to structs and validates them with vali.
+//
+// Requests that can't be decoded or don't pass the validation are
+// answered with RFC 7807 problem details by `Middleware` and `WriteProblem`.
+package valihttp
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"reflect"
+
+	"github.com/tomasmik/vali"
+)
+
+const (
+	// DefaultMaxBodyBytes is the body size limit used if `Options.MaxBodyBytes` is not set.
+	DefaultMaxBodyBytes = 1 << 20
+
+	mediaJSON      = "application/json"
+	mediaForm      = "application/x-www-form-urlencoded"
+	mediaMultipart = "multipart/form-data"
+)
+
+// Options change how the requests are decoded and validated.
+type Options struct {
+	// Validator validates the decoded structs,
+	// the validator returned by `vali.DefaultVali` is used if it's nil.
+	Validator *vali.Vali
+	// Decoders are used to decode the bodies by their media type,
+	// they replace the default JSON, form and multipart form decoders of the same type.
+	Decoders map[string]Decoder
+	// MaxBodyBytes limits the size of the bodies, zero means `DefaultMaxBodyBytes`
+	// is used and a negative value removes the limit.
+	// It's also the memory limit of the multipart forms, their files over it are stored on disk.
+	MaxBodyBytes int64
+}
+
+// Decoder decodes the body of a request in to a struct.
+type Decoder struct {
+	// Decode decodes the body of the request in to `dst`, which is a pointer to a struct.
+	Decode func(r *http.Request, dst interface{}) error
+	// Values returns the values of the request, which are bound to the struct with `vali.BindValues`.
+	// It's used instead of `Decode` if it's set.
+	Values func(r *http.Request) (url.Values, error)
+	// Keys are the struct tag keys holding the names of the fields in the body,
+	// the first one found is used in the problem details. The field name is used if none are found.
+	Keys []string
+}
+
+// Binder decodes and validates requests.
+type Binder struct {
+	v        *vali.Vali
+	decoders map[string]Decoder
+	maxBytes int64
+}
+
+// New returns a binder that uses the given options.
+// Example:
+/*
+
+	b := valihttp.New(valihttp.Options{
+		Validator:    v,
+		MaxBodyBytes: 64 << 10,
+	})
+
+*/
+func New(o Options) *Binder {
+	b := &Binder{
+		v:        o.Validator,
+		maxBytes: o.MaxBodyBytes,
+	}
+	if b.maxBytes == 0 {
+		b.maxBytes = DefaultMaxBodyBytes
+	}
+
+	b.decoders = map[string]Decoder{
+		mediaJSON:      {Decode: decodeJSON, Keys: []string{"json"}},
+		mediaForm:      {Values: formValues(b.maxBytes), Keys: valuesKeys},
+		mediaMultipart: {Values: formValues(b.maxBytes), Keys: valuesKeys},
+	}
+	for typ, d := range o.Decoders {
+		b.decoders[typ] = d
+	}
+	return b
+}
+
+var defaultBinder = New(Options{})
+
+// Bind decodes the request in to a new `T` and validates it with the default options.
+// Requests without a body have their query parameters decoded,
+// other requests are decoded by the decoder of their content type.
+// Example:
+/*
+
+	func createUser(w http.ResponseWriter, r *http.Request) {
+		u, err := valihttp.Bind[User](r)
+		if err != nil {
+			valihttp.WriteProblem(w, r, err)
+			return
+		}
+		...
+	}
+
+*/
+func Bind[T any](r *http.Request) (*T, error) {
+	return BindWith[T](defaultBinder, r)
+}
+
+// BindWith decodes the request in to a new `T` and validates it with the binder.
+func BindWith[T any](b *Binder, r *http.Request) (*T, error) {
+	t := new(T)
+	if err := b.Bind(r, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Bind decodes the request in to `dst`, which has to be a pointer to a struct, and validates it.
+// The returned errors are `*Error`.
+func (b *Binder) Bind(r *http.Request, dst interface{}) error {
+	d, err := b.decoder(r)
+	if err != nil {
+		return err
+	}
+
+	if r.Body != nil && b.maxBytes > 0 {
+		r.Body = &limitedBody{r: r.Body, c: r.Body, n: b.maxBytes}
+	}
+	if d.Values != nil {
+		return b.bindValues(r, d, dst)
+	}
+	if err := d.Decode(r, dst); err != nil {
+		return decodeError(err)
+	}
+	if err := b.validator().Validate(dst); err != nil {
+		return validationError(dst, err, d.Keys)
+	}
+	return nil
+}
+
+// bindValues binds the values returned by the decoder to `dst` and validates it.
+func (b *Binder) bindValues(r *http.Request, d Decoder, dst interface{}) error {
+	vals, err := d.Values(r)
+	if err != nil {
+		return decodeError(err)
+	}
+	if err := b.validator().BindValues(dst, vals); err != nil {
+		return validationError(dst, err, d.Keys)
+	}
+	return nil
+}
+
+func decodeError(err error) error {
+	if errors.Is(err, errTooLarge) {
+		return &Error{Status: http.StatusRequestEntityTooLarge, Err: errTooLarge}
+	}
+	return &Error{Status: http.StatusBadRequest, Err: err}
+}
+
+func validationError(dst interface{}, err error, keys []string) error {
+	return &Error{
+		Status: http.StatusBadRequest,
+		Err:    err,
+		Params: invalidParams(reflect.TypeOf(dst), err, keys),
+	}
+}
+
+// decoder returns the decoder of the request, negotiated by its content type.
+func (b *Binder) decoder(r *http.Request) (Decoder, error) {
+	if !hasBody(r) {
+		return Decoder{Values: queryValues, Keys: valuesKeys}, nil
+	}
+
+	ct := r.Header.Get("Content-Type")
+	typ, _, err := mime.ParseMediaType(ct)
+	if err != nil {
+		return Decoder{}, &Error{Status: http.StatusUnsupportedMediaType, Err: errors.New("invalid content type")}
+	}
+
+	d, ok := b.decoders[typ]
+	if !ok {
+		return Decoder{}, &Error{Status: http.StatusUnsupportedMediaType, Err: errors.New("unsupported content type " + typ)}
+	}
+	return d, nil
+}
+
+func (b *Binder) validator() *vali.Vali {
+	if b.v == nil {
+		return vali.DefaultVali()
+	}
+	return b.v
+}
+
+func hasBody(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodDelete, http.MethodOptions:
+		return false
+	}
+	return r.Body != nil && r.Body != http.NoBody && r.ContentLength != 0
+}
+
+var errTooLarge = errors.New("request body is too large")
+
+// limitedBody returns `errTooLarge` once more than `n` bytes are read.
+type limitedBody struct {
+	r io.Reader
+	c io.Closer
+	n int64
+}
+
+func (l *limitedBody) Read(p []byte) (int, error) {
+	if l.n < 0 {
+		return 0, errTooLarge
+	}
+	if int64(len(p)) > l.n+1 {
+		p = p[:l.n+1]
+	}
+
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+	if l.n < 0 {
+		return n + int(l.n), errTooLarge
+	}
+	return n, err
+}
+
+func (l *limitedBody) Close() error {
+	return l.c.Close()
+}
@@ -0,0 +1,230 @@
+package valihttp
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tomasmik/vali"
+)
+
+type testAddress struct {
+	City string `json:"city" vali:"required|one_of=Vilnius,Kaunas"`
+}
+
+type testUser struct {
+	Name    string       `json:"name" form:"user_name" vali:"required|max=5"`
+	Age     int          `json:"age" form:"age" vali:"min=17"`
+	Tags    []string     `json:"tags" form:"tags" vali:">|one_of=a,b"`
+	Since   *time.Time   `json:"since" form:"since"`
+	Address *testAddress `json:"address" vali:"optional"`
+}
+
+func TestBind(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		contentType string
+		body        string
+		target      string
+		want        *testUser
+		wantStatus  int
+		wantParams  []InvalidParam
+	}{
+		{
+			name:        "valid JSON body, should bind",
+			method:      http.MethodPost,
+			contentType: "application/json; charset=utf-8",
+			body:        `{"name": "a", "age": 18, "tags": ["a"]}`,
+			want:        &testUser{Name: "a", Age: 18, Tags: []string{"a"}},
+		},
+		{
+			name:        "invalid JSON body, should report the fields by their json names",
+			method:      http.MethodPost,
+			contentType: "application/json",
+			body:        `{"name": "abcdef", "age": 18, "tags": ["a", "c"], "address": {}}`,
+			wantStatus:  http.StatusBadRequest,
+			wantParams: []InvalidParam{
+				{Name: "name", Tag: "max", Reason: "abcdef is more than 5"},
+				{Name: "tags[1]", Tag: "one_of", Reason: "must have at least one of [a b]"},
+				{Name: "address.city", Tag: "required", Reason: "empty string"},
+			},
+		},
+		{
+			name:        "malformed JSON body, should be a bad request",
+			method:      http.MethodPost,
+			contentType: "application/json",
+			body:        `{"name": `,
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "several JSON values, should be a bad request",
+			method:      http.MethodPost,
+			contentType: "application/json",
+			body:        `{"name": "a", "age": 18} {}`,
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "valid form body, should bind",
+			method:      http.MethodPost,
+			contentType: "application/x-www-form-urlencoded",
+			body:        "user_name=a&age=18&tags=a&tags=b&since=2020-01-02T00:00:00Z",
+			want: &testUser{Name: "a", Age: 18, Tags: []string{"a", "b"}, Since: func() *time.Time {
+				t := time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)
+				return &t
+			}()},
+		},
+		{
+			name:        "invalid form body, should report the fields by their form names",
+			method:      http.MethodPost,
+			contentType: "application/x-www-form-urlencoded",
+			body:        "user_name=abcdef&age=18",
+			wantStatus:  http.StatusBadRequest,
+			wantParams:  []InvalidParam{{Name: "user_name", Tag: "max", Reason: "abcdef is more than 5"}},
+		},
+		{
+			name:        "form value can't be converted, should report it with the type tag",
+			method:      http.MethodPost,
+			contentType: "application/x-www-form-urlencoded",
+			body:        "user_name=abcdef&age=old",
+			wantStatus:  http.StatusBadRequest,
+			wantParams: []InvalidParam{
+				{Name: "age", Tag: "type", Reason: "old can't be converted to int"},
+				{Name: "user_name", Tag: "max", Reason: "abcdef is more than 5"},
+			},
+		},
+		{
+			name:   "query parameters of a GET request, should bind",
+			method: http.MethodGet,
+			target: "/?user_name=a&age=18",
+			want:   &testUser{Name: "a", Age: 18},
+		},
+		{
+			name:       "invalid query parameters, should be a bad request",
+			method:     http.MethodGet,
+			target:     "/?age=18",
+			wantStatus: http.StatusBadRequest,
+			wantParams: []InvalidParam{{Name: "user_name", Tag: "required", Reason: "empty string"}},
+		},
+		{
+			name:        "unsupported content type, should be rejected",
+			method:      http.MethodPost,
+			contentType: "text/plain",
+			body:        "a",
+			wantStatus:  http.StatusUnsupportedMediaType,
+		},
+		{
+			name:        "invalid content type, should be rejected",
+			method:      http.MethodPost,
+			contentType: "/",
+			body:        "a",
+			wantStatus:  http.StatusUnsupportedMediaType,
+		},
+		{
+			name:        "body is too large, should be rejected",
+			method:      http.MethodPost,
+			contentType: "application/json",
+			body:        `{"name": "` + strings.Repeat("a", DefaultMaxBodyBytes) + `"}`,
+			wantStatus:  http.StatusRequestEntityTooLarge,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := tt.target
+			if target == "" {
+				target = "/"
+			}
+			r := httptest.NewRequest(tt.method, target, strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				r.Header.Set("Content-Type", tt.contentType)
+			}
+
+			got, err := Bind[testUser](r)
+			if tt.wantStatus == 0 {
+				if err != nil {
+					t.Fatalf("Bind() error = %v", err)
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("Bind() = %+v, want %+v", got, tt.want)
+				}
+				return
+			}
+
+			var e *Error
+			if !errors.As(err, &e) {
+				t.Fatalf("Bind() error = %v, want *Error", err)
+			}
+			if e.Status != tt.wantStatus {
+				t.Errorf("Bind() status = %v, want %v", e.Status, tt.wantStatus)
+			}
+			if !reflect.DeepEqual(e.Params, tt.wantParams) {
+				t.Errorf("Bind() params = %v, want %v", e.Params, tt.wantParams)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	v := vali.New()
+	v.SetTagValidation("even", func(s interface{}, o []interface{}) error {
+		if i, ok := vali.GetInt(s); ok && i%2 != 0 {
+			return errors.New("not even")
+		}
+		return nil
+	})
+	type even struct {
+		N int `json:"n" csv:"number" vali:"even"`
+	}
+
+	b := New(Options{
+		Validator:    v,
+		MaxBodyBytes: 8,
+		Decoders: map[string]Decoder{
+			"text/csv": {
+				Decode: func(r *http.Request, dst interface{}) error {
+					dst.(*even).N = 3
+					return nil
+				},
+				Keys: []string{"csv"},
+			},
+		},
+	})
+
+	t.Run("custom validator and decoder, should be used", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("3"))
+		r.Header.Set("Content-Type", "text/csv")
+		_, err := BindWith[even](b, r)
+
+		var e *Error
+		want := []InvalidParam{{Name: "number", Tag: "even", Reason: "not even"}}
+		if !errors.As(err, &e) || !reflect.DeepEqual(e.Params, want) {
+			t.Errorf("BindWith() error = %v, want params %v", err, want)
+		}
+	})
+
+	t.Run("body over the custom limit, should be rejected", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"n": 10000}`))
+		r.Header.Set("Content-Type", "application/json")
+		_, err := BindWith[even](b, r)
+
+		var e *Error
+		if !errors.As(err, &e) || e.Status != http.StatusRequestEntityTooLarge {
+			t.Errorf("BindWith() error = %v, want status %v", err, http.StatusRequestEntityTooLarge)
+		}
+	})
+
+	t.Run("no limit, should read the whole body", func(t *testing.T) {
+		b := New(Options{Validator: v, MaxBodyBytes: -1})
+		body := `{"n": 2, "pad": "` + strings.Repeat("a", DefaultMaxBodyBytes) + `"}`
+		r := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(body))
+		r.Header.Set("Content-Type", "application/json")
+		if got, err := BindWith[even](b, r); err != nil || got.N != 2 {
+			t.Errorf("BindWith() = %v, %v, want 2", got, err)
+		}
+	})
+}
@@ -0,0 +1,146 @@
+package valivet
+
+import (
+	"errors"
+	"fmt"
+	"go/types"
+
+	"github.com/tomasmik/vali"
+)
+
+// kind is the way the values of a type are compared by the built-in tags.
+type kind int
+
+const (
+	kindUnsupported kind = iota
+	kindString
+	kindInt
+	kindUint
+	kindFloat
+	kindSlice
+	kindTime
+	kindDuration
+)
+
+func kindOf(typ types.Type) kind {
+	typ = deref(typ)
+	if n, ok := typ.(*types.Named); ok && n.Obj().Pkg() != nil && n.Obj().Pkg().Path() == "time" {
+		switch n.Obj().Name() {
+		case "Time":
+			return kindTime
+		case "Duration":
+			return kindDuration
+		}
+	}
+
+	switch t := typ.Underlying().(type) {
+	case *types.Slice, *types.Array:
+		return kindSlice
+	case *types.Basic:
+		info := t.Info()
+		switch {
+		case info&types.IsString != 0:
+			return kindString
+		case info&types.IsUnsigned != 0:
+			return kindUint
+		case info&types.IsInteger != 0:
+			return kindInt
+		case info&types.IsFloat != 0:
+			return kindFloat
+		}
+	}
+	return kindUnsupported
+}
+
+// checkArgs reports arguments of the built-in tags which
+// can't be compared to the values of the type at runtime.
+func checkArgs(t vali.ParsedTag, typ types.Type) error {
+	k := kindOf(typ)
+	unsupported := fmt.Errorf("%s is not supported", typ)
+
+	switch t.Name {
+	case "min", "max":
+		if len(t.Args) == 0 {
+			return errors.New("an argument is required")
+		}
+		switch k {
+		case kindString, kindSlice, kindInt, kindUint:
+			return checkArg(t.Args[0], isInt, typ)
+		case kindFloat:
+			return checkArg(t.Args[0], isFloat, typ)
+		case kindTime:
+			return errors.New("time.Time can only be compared to a field pointer")
+		}
+		return unsupported
+	case "eq", "neq":
+		if len(t.Args) == 0 {
+			return errors.New("an argument is required")
+		}
+		switch k {
+		case kindString:
+			return nil
+		case kindSlice, kindInt, kindUint:
+			return checkArg(t.Args[0], isInt, typ)
+		case kindFloat:
+			return checkArg(t.Args[0], isFloat, typ)
+		}
+		return unsupported
+	case "one_of", "none_of":
+		if len(t.Args) == 0 {
+			return errors.New("an argument is required")
+		}
+		check := isInt
+		switch k {
+		case kindString:
+			return nil
+		case kindInt, kindUint:
+		case kindFloat:
+			check = isFloat
+		default:
+			return unsupported
+		}
+		for _, arg := range t.Args {
+			if err := checkArg(arg, check, typ); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "dups":
+		if k != kindSlice {
+			return unsupported
+		}
+	case "required_without":
+		return errors.New("a field pointer argument is required")
+	case "trim", "lower", "upper", "title", "collapse_ws":
+		if k != kindString {
+			return unsupported
+		}
+	case "truncate":
+		if k != kindString {
+			return unsupported
+		}
+		if len(t.Args) == 0 {
+			return errors.New("an argument is required")
+		}
+		return checkArg(t.Args[0], isInt, typ)
+	}
+
+	return nil
+}
+
+func checkArg(arg interface{}, check func(interface{}) bool, typ types.Type) error {
+	if !check(arg) {
+		return fmt.Errorf("argument '%v' can't be compared to %s", arg, typ)
+	}
+	return nil
+}
+
+func isInt(arg interface{}) bool {
+	_, ok := arg.(int64)
+	return ok
+}
+
+func isFloat(arg interface{}) bool {
+	_, ok := arg.(float64)
+	return ok
+}
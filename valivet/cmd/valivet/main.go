@@ -0,0 +1,13 @@
+// Command valivet checks the vali struct tags of the given packages.
+//
+// It can be run on its own or with `go vet -vettool=$(which valivet)`.
+package main
+
+import (
+	"github.com/tomasmik/vali/valivet"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(valivet.Analyzer)
+}
@@ -0,0 +1,15 @@
+module github.com/tomasmik/vali/valivet
+
+go 1.22.0
+
+require (
+	github.com/tomasmik/vali v0.0.0
+	golang.org/x/tools v0.26.0
+)
+
+require (
+	golang.org/x/mod v0.21.0 // indirect
+	golang.org/x/sync v0.8.0 // indirect
+)
+
+replace github.com/tomasmik/vali => ../
@@ -0,0 +1,46 @@
+package a
+
+import "time"
+
+type Valid struct {
+	Name    string    `vali:"required|min=3|max=64|trim|one_of=a,b"`
+	Nick    string    `vali:"required_without=*Name"`
+	Age     int       `vali:"optional|min=18|eq=1||eq=2"`
+	Score   float64   `vali:"max=5.5"`
+	Tags    []string  `vali:"min=1|dups|>|not(none_of=a,b)"`
+	Groups  string    `vali:"required@create|optional@update"`
+	Since   time.Time `vali:"min=*Until"`
+	Until   time.Time
+	Custom  string `vali:"email"`
+	Ignored string `vali:"-"`
+}
+
+type Base struct {
+	CreatedAt time.Time
+}
+
+type Embedded struct {
+	Base
+	UpdatedAt time.Time `vali:"min=*CreatedAt"`
+}
+
+type NotFlattened struct {
+	Base      `vali:"no_flatten"`
+	UpdatedAt time.Time `vali:"min=*CreatedAt"` // want `field UpdatedAt: tag 'min' points to field 'CreatedAt' which doesn't exist`
+}
+
+type Invalid struct {
+	Unknown  string        `vali:"required|unknwon"`       // want `field Unknown: unknown tag 'unknwon'`
+	Ref      string        `vali:"required_without=*Nope"` // want `field Ref: tag 'required_without' points to field 'Nope' which doesn't exist`
+	Dive     string        `vali:">|required"`             // want `field Dive: > can't be used with string, only slices and arrays are supported`
+	Float    float64       `vali:"min=5"`                  // want `field Float: tag 'min': argument '5' can't be compared to float64`
+	Int      int           `vali:"one_of=1,a"`             // want `field Int: tag 'one_of': argument 'a' can't be compared to int`
+	Time     time.Time     `vali:"min=2020"`               // want `field Time: tag 'min': time.Time can only be compared to a field pointer`
+	Duration time.Duration `vali:"max=5"`                  // want `field Duration: tag 'max': time.Duration is not supported`
+	Conflict string        `vali:"required|optional"`      // want `field Conflict: a field can only have one of: optional, required, required_without`
+	NoArg    int           `vali:"min"`                    // want `field NoArg: tag 'min': an argument is required`
+	Without  string        `vali:"required_without"`       // want `field Without: tag 'required_without': a field pointer argument is required`
+	Alt      []int         `vali:">|eq=1||eq=b"`           // want `field Alt: tag 'eq': argument 'b' can't be compared to int`
+	Dups     int           `vali:"dups"`                   // want `field Dups: tag 'dups': int is not supported`
+	NotOr    string        `vali:"not(eq=a||eq=b)"`        // want `field NotOr: 'not\(eq=a\|\|eq=b\)' can't negate an OR group, negate every alternative instead`
+}
@@ -0,0 +1,239 @@
+// Package valivet defines an analyzer which checks the vali struct tags.
+//
+// The tags are parsed with the same grammar `vali.Validate` uses and
+// the analyzer reports unknown tags, field pointers to fields that don't exist,
+// dives in to fields that are not slices or arrays, arguments
+// that can't be compared to the field and conflicting tags.
+//
+// It can be used with `go vet -vettool=$(which valivet)`
+// using the command in `cmd/valivet` or loaded by golangci-lint with `New`.
+package valivet
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"reflect"
+	"strings"
+
+	"github.com/tomasmik/vali"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// Analyzer reports invalid vali struct tags.
+var Analyzer = &analysis.Analyzer{
+	Name:     "valivet",
+	Doc:      "check that vali struct tags are valid",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+var (
+	// tagName is the struct tag key that holds the vali tags,
+	// it has to be changed if `RenameTag` is used.
+	tagName = "vali"
+	// extraTags holds the tags registered with `SetTagValidation`,
+	// `SetTagTransform` or `RegisterAlias`, which the analyzer can't see.
+	extraTags = ""
+)
+
+func init() {
+	Analyzer.Flags.StringVar(&tagName, "tag", tagName, "struct tag key of the vali tags")
+	Analyzer.Flags.StringVar(&extraTags, "custom", extraTags, "comma separated list of custom tags and aliases")
+}
+
+// New returns the analyzers of the package,
+// it's the entry point of golangci-lint plugins.
+func New(conf interface{}) ([]*analysis.Analyzer, error) {
+	return []*analysis.Analyzer{Analyzer}, nil
+}
+
+const (
+	dive               = ">"
+	requiredTag        = "required"
+	requiredWithoutTag = "required_without"
+	optionalTag        = "optional"
+)
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	v := vali.New()
+	known := map[string]struct{}{}
+	for _, t := range strings.Split(extraTags, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			known[t] = struct{}{}
+		}
+	}
+	c := &checker{pass: pass, v: v, known: known}
+
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	insp.Preorder([]ast.Node{(*ast.StructType)(nil)}, func(n ast.Node) {
+		node := n.(*ast.StructType)
+		st, ok := pass.TypesInfo.TypeOf(node).(*types.Struct)
+		if !ok {
+			return
+		}
+
+		i := 0
+		for _, f := range node.Fields.List {
+			count := len(f.Names)
+			if count == 0 {
+				count = 1
+			}
+			if f.Tag != nil {
+				for j := 0; j < count; j++ {
+					c.checkField(f.Tag, st, st.Field(i+j), st.Tag(i+j))
+				}
+			}
+			i += count
+		}
+	})
+
+	return nil, nil
+}
+
+type checker struct {
+	pass  *analysis.Pass
+	v     *vali.Vali
+	known map[string]struct{}
+}
+
+func (c *checker) checkField(node ast.Node, st *types.Struct, f *types.Var, structTag string) {
+	vtag, ok := reflect.StructTag(structTag).Lookup(tagName)
+	if !ok || vtag == "" || vtag == "-" {
+		return
+	}
+
+	report := func(format string, args ...interface{}) {
+		c.pass.Reportf(node.Pos(), "field %s: %s", f.Name(), fmt.Sprintf(format, args...))
+	}
+
+	if err := vali.CheckSyntax(vtag); err != nil {
+		report("%s", err)
+		return
+	}
+
+	tags := vali.ParseTags(vtag)
+	if err := checkConflicts(tags); err != nil {
+		report("%s", err)
+	}
+
+	typ := f.Type()
+	for _, t := range tags {
+		if t.Name == dive {
+			elem, ok := elemType(typ)
+			if !ok {
+				report("%s can't be used with %s, only slices and arrays are supported", dive, typ)
+				return
+			}
+			typ = elem
+			continue
+		}
+
+		c.checkTag(report, st, typ, t)
+	}
+}
+
+// checkConflicts reports the conflicting tags the same way `Validate` does,
+// fields with grouped tags are only checked when they're validated.
+func checkConflicts(tags []vali.ParsedTag) error {
+	found := map[string]struct{}{}
+	for _, t := range tags {
+		if len(t.Groups) > 0 {
+			return nil
+		}
+		if t.Negate || len(t.Alts) > 0 {
+			continue
+		}
+		switch t.Name {
+		case optionalTag, requiredWithoutTag, requiredTag:
+			found[t.Name] = struct{}{}
+		}
+	}
+
+	if len(found) > 1 {
+		return fmt.Errorf("a field can only have one of: %s, %s, %s", optionalTag, requiredTag, requiredWithoutTag)
+	}
+	return nil
+}
+
+func (c *checker) checkTag(report func(string, ...interface{}), st *types.Struct, typ types.Type, t vali.ParsedTag) {
+	if len(t.Alts) > 0 {
+		for _, alt := range t.Alts {
+			for _, at := range alt {
+				c.checkTag(report, st, typ, at)
+			}
+		}
+		return
+	}
+
+	if _, ok := c.known[t.Name]; !ok && !c.v.HasTag(t.Name) {
+		report("unknown tag '%s'", t.Name)
+		return
+	}
+
+	for _, ref := range t.Refs {
+		if !hasField(st, ref) {
+			report("tag '%s' points to field '%s' which doesn't exist", t.Name, ref)
+		}
+	}
+	if len(t.Refs) > 0 {
+		// The type of the field pointed to is only known at runtime
+		return
+	}
+
+	if err := checkArgs(t, typ); err != nil {
+		report("tag '%s': %s", t.Name, err)
+	}
+}
+
+// hasField reports whether the struct has the field
+// the same way field pointers are resolved by `Validate`,
+// fields of embedded structs are promoted unless they have the `no_flatten` tag.
+func hasField(st *types.Struct, name string) bool {
+	for i := 0; i < st.NumFields(); i++ {
+		f := st.Field(i)
+		if f.Name() == name {
+			return true
+		}
+		if !f.Embedded() || noFlatten(st.Tag(i)) {
+			continue
+		}
+		if est, ok := deref(f.Type()).Underlying().(*types.Struct); ok && hasField(est, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func noFlatten(structTag string) bool {
+	vtag, _ := reflect.StructTag(structTag).Lookup(tagName)
+	for _, t := range vali.ParseTags(vtag) {
+		if t.Name == "no_flatten" {
+			return true
+		}
+	}
+	return false
+}
+
+// elemType returns the element type of slices and arrays.
+func elemType(typ types.Type) (types.Type, bool) {
+	switch t := deref(typ).Underlying().(type) {
+	case *types.Slice:
+		return t.Elem(), true
+	case *types.Array:
+		return t.Elem(), true
+	}
+	return nil, false
+}
+
+func deref(typ types.Type) types.Type {
+	for {
+		p, ok := typ.Underlying().(*types.Pointer)
+		if !ok {
+			return typ
+		}
+		typ = p.Elem()
+	}
+}
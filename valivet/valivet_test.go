@@ -0,0 +1,14 @@
+package valivet
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	if err := Analyzer.Flags.Set("custom", "email"); err != nil {
+		t.Fatal(err)
+	}
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "a")
+}
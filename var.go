@@ -0,0 +1,102 @@
+package vali
+
+import (
+	"errors"
+	"reflect"
+)
+
+// Var validates a single value `s` using the given tag string.
+// The tag string uses the same syntax as the struct field tags,
+// allowing to validate function arguments, slices and other
+// values without defining a struct for them.
+// Field pointers can't be used as there are no fields to point to,
+// see `VarWithValue` for that.
+// Example:
+/*
+
+	err := v.Var([]string{"a", "b"}, "required|min=1|>|one_of=a,b")
+
+*/
+func (v *Vali) Var(s interface{}, tg string) error {
+	tags, err := v.expandAliases(parseTags(tg))
+	if err != nil {
+		return newAggErr().addErr(err)
+	}
+
+	return v.validateVar(s, resolveTags(tags, func(string) (interface{}, bool) {
+		return nil, false
+	}))
+}
+
+// VarWithValue validates a single value `s` against the value `o`
+// using the given tag string.
+// The value `o` is used as the argument of every tag that has no arguments,
+// while any field pointer (a bare `*` is enough) is resolved to `o`.
+// Example:
+/*
+
+	// validate that the password matches its confirmation.
+	err := v.VarWithValue(pass, confirm, "required|eq")
+
+	// validate that the end is after the start.
+	err := v.VarWithValue(end, start, "min=*")
+
+*/
+func (v *Vali) VarWithValue(s, o interface{}, tg string) error {
+	tags, err := v.expandAliases(parseTags(tg))
+	if err != nil {
+		return newAggErr().addErr(err)
+	}
+
+	tags = resolveTags(tags, func(string) (interface{}, bool) {
+		return DerefInterface(o), true
+	})
+	return v.validateVar(s, withArg(tags, DerefInterface(o)))
+}
+
+// withArg adds the argument to the tags that have none,
+// including the alternatives of the OR groups.
+func withArg(tags []tag, arg interface{}) []tag {
+	for i := range tags {
+		if len(tags[i].alts) > 0 {
+			alts := make([][]tag, 0, len(tags[i].alts))
+			for _, alt := range tags[i].alts {
+				alts = append(alts, withArg(append([]tag(nil), alt...), arg))
+			}
+			tags[i].alts = alts
+			continue
+		}
+		if len(tags[i].args) == 0 {
+			tags[i].args = append(tags[i].args, arg)
+		}
+	}
+	return tags
+}
+
+func (v *Vali) validateVar(s interface{}, tags []tag) error {
+	errs := newAggErr()
+	if err := checkSyntax(tags); err != nil {
+		return errs.addErr(err)
+	}
+	// Groups can't be selected for a single value
+	tags = activeTags(tags, nil)
+	if len(tags) == 0 {
+		return nil
+	}
+
+	if err := validateTags(tagSliceToMap(tags)); err != nil {
+		return errs.addErr(err)
+	}
+
+	cmp := []interface{}{DerefInterface(s)}
+	dst := []reflect.Value{{}}
+	if err := v.validateField("", "", cmp, dst, tags, newState(ValidateOptions{})); err != nil {
+		var b *bubbleErr
+		if errors.As(err, &b) {
+			return b.err
+		}
+		errs.addErr(err)
+	}
+
+	return errs.toError()
+}
@@ -0,0 +1,154 @@
+package vali
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestVar(t *testing.T) {
+	one := 1
+	tests := []struct {
+		name string
+		s    interface{}
+		tag  string
+		want error
+	}{
+		{
+			name: "valid slice, should not error",
+			s:    []string{"a", "b"},
+			tag:  "required|min=1|>|one_of=a,b",
+			want: nil,
+		},
+		{
+			name: "slice has a value that's not allowed, should error",
+			s:    []string{"a", "c"},
+			tag:  "required|min=1|>|one_of=a,b",
+			want: newAggErr().addErr(withPath(newTagError("", oneofTag, errors.New("must have at least one of [a b]")), "[1]")),
+		},
+		{
+			name: "int is less than min, should error",
+			s:    1,
+			tag:  "min=2",
+			want: newAggErr().addErr(newTagError("", minTag, errors.New("1 is less than 2"))),
+		},
+		{
+			name: "pointer to int is dereferenced, should not error",
+			s:    &one,
+			tag:  "eq=1",
+			want: nil,
+		},
+		{
+			name: "nil value is required, should error",
+			s:    nil,
+			tag:  "required",
+			want: newAggErr().addErr(newTagError("", requiredTag, errors.New("value is nil"))),
+		},
+		{
+			name: "nil value is optional, should not error",
+			s:    nil,
+			tag:  "optional|min=2",
+			want: nil,
+		},
+		{
+			name: "empty tag, should not error",
+			s:    1,
+			tag:  "",
+			want: nil,
+		},
+		{
+			name: "both required and optional, should error",
+			s:    1,
+			tag:  "required|optional",
+			want: newAggErr().addErr(errors.New("a field can only have one of: optional, required, required_without")),
+		},
+	}
+
+	v := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := v.Var(tt.s, tt.tag); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Vali.Var() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVarWithValue(t *testing.T) {
+	tests := []struct {
+		name string
+		s    interface{}
+		o    interface{}
+		tag  string
+		want error
+	}{
+		{
+			name: "values are equal, should not error",
+			s:    "pass",
+			o:    "pass",
+			tag:  "required|eq",
+			want: nil,
+		},
+		{
+			name: "values are not equal, should error",
+			s:    "pass",
+			o:    "other",
+			tag:  "required|eq",
+			want: newAggErr().addErr(newTagError("", eqTag, errors.New("pass is not equal to other"))),
+		},
+		{
+			name: "value is less than the pointed value, should error",
+			s:    1,
+			o:    2,
+			tag:  "min=*",
+			want: newAggErr().addErr(newTagError("", minTag, errors.New("1 is less than 2"))),
+		},
+		{
+			name: "value is empty but the other one is not, should not error",
+			s:    "",
+			o:    "a",
+			tag:  "required_without",
+			want: nil,
+		},
+		{
+			name: "both values are empty, should error",
+			s:    "",
+			o:    "",
+			tag:  "required_without",
+			want: newAggErr().addErr(newTagError("", requiredWithoutTag, errors.New("empty string"))),
+		},
+		{
+			name: "OR group alternatives get the value, should not error",
+			s:    1,
+			o:    2,
+			tag:  "eq||neq",
+			want: nil,
+		},
+		{
+			name: "negated OR group alternative gets the value, should not error",
+			s:    1,
+			o:    2,
+			tag:  "eq||not(eq)",
+			want: nil,
+		},
+		{
+			name: "every OR group alternative fails with the value, should error",
+			s:    1,
+			o:    1,
+			tag:  "neq||not(eq)",
+			want: newAggErr().addErr(newTagError("", "neq||not(eq)", &OrErr{Sl: []error{
+				newTagError("", neqTag, errors.New("1 is equal to 1")),
+				newTagError("", "not(eq)", errors.New("must not pass 'eq' tag")),
+			}})),
+		},
+	}
+
+	v := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := v.VarWithValue(tt.s, tt.o, tt.tag); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Vali.VarWithValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}